@@ -1,9 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/ericstrs/bp/internal/config"
 	s "github.com/ericstrs/bp/internal/storage"
 	t "github.com/ericstrs/bp/internal/tasks"
 	"github.com/ericstrs/bp/internal/ui"
@@ -16,6 +22,12 @@ func run() {
 		case "form":
 			ui.InitForm()
 			return
+		case "help":
+			cfg, _ := loadConfig()
+			if err := ui.PrintHelp(os.Stdout, cfg); err != nil {
+				log.Fatalf("Error in config: %v", err)
+			}
+			return
 		}
 	}
 
@@ -35,18 +47,319 @@ func run() {
 	if err := store.Load("boards", &tree); err != nil {
 		log.Fatalf("Error loading boards: %v", err)
 	}
+	list.SpreadPriorities()
+	tree.SpreadPriorities()
+	queries := new(t.SavedQueries)
+	if err := store.Load("queries", &queries); err != nil {
+		log.Fatalf("Error loading queries: %v", err)
+	}
 
-	tui := new(ui.TUI)
-	tui.Init(list, tree)
+	save := func() error {
+		if err := store.Save("list", list); err != nil {
+			return fmt.Errorf("saving list: %v", err)
+		}
+		if err := store.Save("boards", tree); err != nil {
+			return fmt.Errorf("saving board: %v", err)
+		}
+		if err := store.Save("queries", queries); err != nil {
+			return fmt.Errorf("saving queries: %v", err)
+		}
+		return nil
+	}
 
-	if err := store.Save("list", list); err != nil {
-		log.Printf("Error saving list: %v\n.", err)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "move":
+			if err := moveCmd(tree, os.Args[2:]); err != nil {
+				log.Fatalf("Error moving task: %v", err)
+			}
+		case "delete":
+			if err := deleteCmd(tree, os.Args[2:]); err != nil {
+				log.Fatalf("Error deleting: %v", err)
+			}
+		case "search":
+			if err := searchCmd(list, tree, os.Args[2:]); err != nil {
+				log.Fatalf("Error searching tasks: %v", err)
+			}
+			return
+		case "query":
+			changed, err := queryCmd(list, tree, queries, os.Args[2:])
+			if err != nil {
+				log.Fatalf("Error querying tasks: %v", err)
+			}
+			// Only saving and deleting queries changes the data.
+			if !changed {
+				return
+			}
+		default:
+			log.Fatalf("Unknown command %q", os.Args[1])
+		}
+	} else {
+		cfg, cfgPath := loadConfig()
+		tui := new(ui.TUI)
+		if err := tui.SetConfig(cfg); err != nil {
+			log.Fatalf("Error in config %s: %v", cfgPath, err)
+		}
+		tui.SetQueries(queries)
+		tui.SetSaveFunc(save)
+
+		// The UI state is restored on a best effort basis. A broken state
+		// file only loses the last position.
+		state := new(ui.State)
+		if err := store.Load("state", state); err != nil {
+			log.Printf("Error loading state: %v", err)
+			state = new(ui.State)
+		}
+		tui.SetState(state)
+
+		// Log to a file while the terminal is taken over by the TUI.
+		logFile, err := os.OpenFile(yamlPath+".log", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Fatalf("Error opening log file: %v", err)
+		}
+		log.SetOutput(logFile)
+		tui.Init(list, tree)
+		log.SetOutput(os.Stderr)
+		logFile.Close()
+
+		if err := store.Save("state", tui.State()); err != nil {
+			log.Printf("Error saving state: %v", err)
+		}
 	}
-	if err := store.Save("boards", tree); err != nil {
-		log.Printf("Error saving board: %v.\n", err)
+
+	if err := save(); err != nil {
+		log.Printf("Error %v.\n", err)
 	}
 }
 
+// moveCmd moves a board task, and its sub-board if it has one, to the
+// top of a column of another board.
+//
+// Usage: bp move <task id> <board id> <column>
+//
+// The column is either the column title or its one-based position.
+func moveCmd(tree *t.BoardTree, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: bp move <task id> <board id> <column>")
+	}
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task id %q", args[0])
+	}
+	boardID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid board id %q", args[1])
+	}
+
+	src, srcCol, idx, err := tree.FindTask(taskID)
+	if err != nil {
+		return err
+	}
+	dst, err := tree.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+	dstCol, err := findColumn(dst, args[2])
+	if err != nil {
+		return err
+	}
+
+	name := src.Columns[srcCol].Tasks[idx].GetName()
+	if col := dst.Columns[dstCol]; (src != dst || srcCol != dstCol) && col.AtWIPLimit() {
+		fmt.Fprintf(os.Stderr, "Warning: column %q is over its WIP limit of %d\n", col.GetTitle(), col.GetWIPLimit())
+	}
+	if err := tree.MoveTask(src, srcCol, idx, dst, dstCol, 0); err != nil {
+		return err
+	}
+	fmt.Printf("Moved %q to %s › %s\n", name, tree.PathString(dst.GetID()), dst.Columns[dstCol].GetTitle())
+	return nil
+}
+
+// deleteCmd deletes a root board, a board column or a board task, along
+// with the sub-boards beneath it and their tasks. It lists what is
+// removed and asks for confirmation, unless --yes is given.
+//
+// Usage:
+//
+//	bp delete [--yes] board <board id>
+//	bp delete [--yes] column <board id> <column>
+//	bp delete [--yes] task <task id>
+//
+// The column is either the column title or its one-based position.
+func deleteCmd(tree *t.BoardTree, args []string) error {
+	yes, args := yesFlag(args)
+	usage := errors.New("usage: bp delete [--yes] board <board id> | column <board id> <column> | task <task id>")
+	if len(args) < 2 {
+		return usage
+	}
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid id %q", args[1])
+	}
+
+	var text string
+	var del func() error
+	switch {
+	case args[0] == "board" && len(args) == 2:
+		b, err := tree.GetBoard(id)
+		if err != nil {
+			return err
+		}
+		if _, err := tree.GetParentBoard(id); err == nil {
+			return fmt.Errorf("board %d is a sub-board, delete its parent task instead", id)
+		}
+		n, m := tree.CountBoard(b)
+		text = ui.DeleteText("board", b.GetTitle(), n, m)
+		del = func() error { return tree.DeleteBoard(b) }
+	case args[0] == "column" && len(args) == 3:
+		b, err := tree.GetBoard(id)
+		if err != nil {
+			return err
+		}
+		col, err := findColumn(b, args[2])
+		if err != nil {
+			return err
+		}
+		n, m := tree.CountColumn(b.Columns[col])
+		text = ui.DeleteText("column", b.Columns[col].GetTitle(), n, m)
+		del = func() error { return tree.DeleteColumn(b, col) }
+	case args[0] == "task" && len(args) == 2:
+		b, col, idx, err := tree.FindTask(id)
+		if err != nil {
+			return err
+		}
+		task := b.Columns[col].Tasks[idx]
+		n, m := tree.CountTask(task)
+		text = ui.DeleteText("task", task.GetName(), n-1, m)
+		del = func() error { return tree.DeleteTask(b, col, idx) }
+	default:
+		return usage
+	}
+
+	if !yes && !confirm(text) {
+		return errors.New("deletion not confirmed")
+	}
+	return del()
+}
+
+// searchCmd prints the id, location and name of every task whose name
+// or description contains all words of the query.
+//
+// Usage: bp search <query>
+func searchCmd(list *t.TodoList, tree *t.BoardTree, args []string) error {
+	query := strings.Join(args, " ")
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("usage: bp search <query>")
+	}
+	for _, r := range t.Search(list, tree, query) {
+		fmt.Printf("%d\t%s\t%s\n", r.Task.GetID(), r.Location(list, tree), r.Task.GetName())
+	}
+	return nil
+}
+
+// queryCmd prints the id, location and name of every task matching a
+// query, or manages saved queries.
+//
+// Usage:
+//
+//	bp query <query>               print tasks matching the query
+//	bp query @<name>               print tasks matching a saved query
+//	bp query --save <name> <query> save a query under a name
+//	bp query --delete <name>       delete a saved query
+//	bp query --list                list saved queries
+//
+// It reports whether the saved queries changed.
+func queryCmd(list *t.TodoList, tree *t.BoardTree, queries *t.SavedQueries, args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, fmt.Errorf("usage: bp query <query>")
+	}
+	switch args[0] {
+	case "--save":
+		if len(args) < 3 {
+			return false, fmt.Errorf("usage: bp query --save <name> <query>")
+		}
+		return true, queries.Save(args[1], strings.Join(args[2:], " "))
+	case "--delete":
+		if len(args) != 2 {
+			return false, fmt.Errorf("usage: bp query --delete <name>")
+		}
+		return true, queries.Remove(args[1])
+	case "--list":
+		for _, q := range queries.Queries {
+			fmt.Printf("%s\t%s\n", q.Name, q.Query)
+		}
+		return false, nil
+	}
+
+	source := strings.Join(args, " ")
+	if strings.HasPrefix(source, "@") {
+		saved, ok := queries.Get(source[1:])
+		if !ok {
+			return false, fmt.Errorf("no query saved as %q", source[1:])
+		}
+		source = saved
+	}
+	q, err := t.ParseQuery(source)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range t.Filter(list, tree, q) {
+		fmt.Printf("%d\t%s\t%s\n", r.Task.GetID(), r.Location(list, tree), r.Task.GetName())
+	}
+	return false, nil
+}
+
+// yesFlag reports whether the arguments contain the --yes flag, which
+// skips confirmations, and returns the other arguments.
+func yesFlag(args []string) (bool, []string) {
+	yes := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--yes" || arg == "-y" {
+			yes = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return yes, rest
+}
+
+// confirm asks a yes or no question on the terminal and reports
+// whether it was answered with yes.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// findColumn returns the index of a board column given its title or
+// its one-based position.
+func findColumn(b *t.Board, col string) (int, error) {
+	for i, c := range b.GetColumns() {
+		if strings.EqualFold(c.GetTitle(), col) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(col); err == nil && n >= 1 && n <= len(b.GetColumns()) {
+		return n - 1, nil
+	}
+	return 0, fmt.Errorf("board %q has no column %q", b.GetTitle(), col)
+}
+
 func main() {
 	run()
 }
+
+// loadConfig loads the user configuration and returns it with its path.
+func loadConfig() (*config.Config, string) {
+	path, err := config.Path()
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+	return cfg, path
+}
@@ -0,0 +1,142 @@
+// Package config loads the user configuration of bp from a YAML file
+// in the user configuration directory, such as
+// $XDG_CONFIG_HOME/bp/config.yaml.
+//
+// Key bindings are configured per context. Each action, the name of a
+// command optionally followed by its arguments, maps to a key or a list
+// of keys:
+//
+//	keys:
+//	  list:
+//	    move-down: [J, Ctrl+Down]
+//	    toggle-done: Enter
+//	  task:
+//	    send 1: F1
+//
+// Configuring an action replaces its default keys, and an empty list
+// unbinds it.
+//
+// The theme selects the colors of the user interface, and colors
+// override single colors of the theme:
+//
+//	theme: light
+//	colors:
+//	  description: "#af5f00"
+//	  tree-board: green
+//
+// Deleting a board, a column or a task with a sub-board always asks for
+// confirmation. Deleting other tasks does too, unless skipped:
+//
+//	confirm:
+//	  skip-leaf-tasks: true
+//
+// Board columns are never narrower than a minimum width, in cells. The
+// columns that don't fit are paged through:
+//
+//	board:
+//	  min-column-width: 30
+//
+// Adding a task to a column at its WIP limit warns, or fails if the
+// limits block:
+//
+//	board:
+//	  wip-limits: block
+//
+// Tasks with a sub-board can move to the done column of their board
+// once every task on the sub-board is done:
+//
+//	board:
+//	  complete-parents: true
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the user configuration.
+type Config struct {
+	// Keys maps a context, such as "list" or "task", to the keys of the
+	// actions in that context.
+	Keys map[string]map[string]Keys `yaml:"keys"`
+
+	// Theme is the name of the color theme, such as "light".
+	Theme string `yaml:"theme"`
+	// Colors maps theme color names, such as "border", to a color name
+	// or hex value.
+	Colors map[string]string `yaml:"colors"`
+
+	// Confirm holds the settings of confirmation prompts.
+	Confirm Confirm `yaml:"confirm"`
+
+	// Board holds the settings of the board view.
+	Board Board `yaml:"board"`
+}
+
+// Confirm holds the settings of confirmation prompts.
+type Confirm struct {
+	// SkipLeafTasks deletes tasks without a sub-board without asking.
+	SkipLeafTasks bool `yaml:"skip-leaf-tasks"`
+}
+
+// Board holds the settings of the board view.
+type Board struct {
+	// MinColumnWidth is the minimum width of a column, or zero for the
+	// default.
+	MinColumnWidth int `yaml:"min-column-width"`
+	// WIPLimits is "warn", the default, to warn when a task exceeds the
+	// WIP limit of a column, or "block" to refuse it.
+	WIPLimits string `yaml:"wip-limits"`
+	// CompleteParents moves a task to the done column of its board once
+	// every task on its sub-board is done.
+	CompleteParents bool `yaml:"complete-parents"`
+}
+
+// Keys is a list of key names. In YAML it is either a single key name
+// or a sequence of key names.
+type Keys []string
+
+// UnmarshalYAML decodes a single key name or a sequence of key names.
+func (k *Keys) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*k = Keys{value.Value}
+		return nil
+	}
+	var keys []string
+	if err := value.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// Path returns the path of the configuration file.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %v", err)
+	}
+	return filepath.Join(dir, "bp", "config.yaml"), nil
+}
+
+// Load reads the configuration file at the given path. A missing file
+// yields an empty configuration.
+func Load(path string) (*Config, error) {
+	c := new(Config)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func ExampleLoad() {
+	dir, _ := os.MkdirTemp("", "bp")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte(`
+keys:
+  list:
+    move-down: [J, Ctrl+Down]
+    toggle-done: Enter
+    yank: []
+`), 0644)
+
+	c, err := Load(path)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(c.Keys["list"]["move-down"])
+	fmt.Println(c.Keys["list"]["toggle-done"])
+	fmt.Println(len(c.Keys["list"]["yank"]))
+
+	// Output:
+	// [J Ctrl+Down]
+	// [Enter]
+	// 0
+}
+
+func ExampleLoad_missing() {
+	c, err := Load(filepath.Join(os.TempDir(), "bp-missing", "config.yaml"))
+	fmt.Println(len(c.Keys), err)
+
+	// Output:
+	// 0 <nil>
+}
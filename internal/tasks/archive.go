@@ -0,0 +1,60 @@
+package tasks
+
+import "errors"
+
+// ArchiveDone moves every done task of the todo list to its archive
+// and returns the number of archived tasks.
+func (t *TodoList) ArchiveDone() int {
+	kept := t.Tasks[:0]
+	n := 0
+	for _, task := range t.Tasks {
+		if task.GetIsDone() {
+			t.Archived = append(t.Archived, task)
+			n++
+			continue
+		}
+		kept = append(kept, task)
+	}
+	t.Tasks = kept
+	return n
+}
+
+// ArchiveTask moves a task of the given board column to the archive of
+// the board tree. Tasks with a sub-board can't be archived, since the
+// sub-board would be left without a parent task.
+func (tree *BoardTree) ArchiveTask(b *Board, col, idx int) error {
+	if col < 0 || col >= len(b.Columns) {
+		return errors.New("column index out of range")
+	}
+	task, err := b.Columns[col].GetTask(idx)
+	if err != nil {
+		return err
+	}
+	if task.GetHasChild() {
+		return errors.New("cannot archive a task with a sub-board")
+	}
+	removed, err := b.Columns[col].Remove(idx)
+	if err != nil {
+		return err
+	}
+	tree.Archived = append(tree.Archived, *removed)
+	return nil
+}
+
+// ArchiveColumn moves every task of the given board column that has no
+// sub-board to the archive of the board tree and returns the number of
+// archived tasks.
+func (tree *BoardTree) ArchiveColumn(b *Board, col int) int {
+	n := 0
+	for i := len(b.Columns[col].Tasks) - 1; i >= 0; i-- {
+		if tree.ArchiveTask(b, col, i) == nil {
+			n++
+		}
+	}
+	// Archived tasks were removed last to first, restore their order.
+	archived := tree.Archived[len(tree.Archived)-n:]
+	for i, j := 0, len(archived)-1; i < j; i, j = i+1, j-1 {
+		archived[i], archived[j] = archived[j], archived[i]
+	}
+	return n
+}
@@ -0,0 +1,51 @@
+package tasks
+
+import "fmt"
+
+func ExampleTodoList_ArchiveDone() {
+	list := TodoList{Tasks: []TodoTask{
+		{Task: &Task{Name: "code", Done: true}},
+		{Task: &Task{Name: "read"}},
+		{Task: &Task{Name: "eat", Done: true}},
+	}}
+	fmt.Println(list.ArchiveDone())
+	for _, task := range list.Tasks {
+		fmt.Println("Task:", task.GetName())
+	}
+	for _, task := range list.Archived {
+		fmt.Println("Archived:", task.GetName())
+	}
+
+	// Output:
+	// 2
+	// Task: read
+	// Archived: code
+	// Archived: eat
+}
+
+func ExampleBoardTree_ArchiveColumn() {
+	tree := new(BoardTree)
+	board := tree.NewBoard("Project")
+	tree.AddRoot(board)
+	board.Columns[2].Tasks = []BoardTask{
+		{Task: &Task{Name: "design"}},
+		{Task: &Task{Name: "build"}, HasChild: true, ChildID: 2},
+		{Task: &Task{Name: "ship"}},
+	}
+
+	fmt.Println(tree.ArchiveColumn(board, 2))
+	for _, task := range board.Columns[2].Tasks {
+		fmt.Println("Task:", task.GetName())
+	}
+	for _, task := range tree.Archived {
+		fmt.Println("Archived:", task.GetName())
+	}
+	fmt.Println(tree.ArchiveTask(board, 2, 0))
+
+	// Output:
+	// 2
+	// Task: build
+	// Archived: design
+	// Archived: ship
+	// cannot archive a task with a sub-board
+}
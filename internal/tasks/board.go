@@ -4,7 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"strings"
+	"time"
 )
 
 type BoardBuffer struct {
@@ -33,6 +34,10 @@ type BoardTree struct {
 
 	BoardCounter int `yaml:"board_counter"`
 	TaskCounter  int `yaml:"task_counter"`
+
+	Archived []BoardTask `yaml:"archived,omitempty"` // tasks removed by archiving
+
+	Collapsed []string `yaml:"-"` // keys of the collapsed tree view nodes, saved with the UI state
 }
 
 type Board struct {
@@ -40,19 +45,25 @@ type Board struct {
 	Title      string        `yaml:"title"`
 	ParentTask *BoardTask    `yaml:"-"` //`yaml:"parent_task_id"`
 	Columns    []BoardColumn `yaml:"columns"`
+	Lanes      []string      `yaml:"lanes,omitempty"` // swimlanes, in display order
 
 	Children []int `yaml:"children"`
 }
 
 type BoardColumn struct {
-	Title string      `yaml:"title"`
-	Tasks []BoardTask `yaml:"tasks"`
+	Title       string      `yaml:"title"`
+	Tasks       []BoardTask `yaml:"tasks"`
+	Prioritizer string      `yaml:"prioritizer,omitempty"` // name of the prioritizer ordering the tasks
+	Collapsed   bool        `yaml:"collapsed,omitempty"`   // shown narrow, without its tasks
+	WIPLimit    int         `yaml:"wip_limit,omitempty"`   // maximum number of tasks, zero for no limit
+	DoneColumn  bool        `yaml:"done_column,omitempty"` // tasks entering the column are done
 }
 
 type BoardTask struct {
 	*Task
-	ChildID  int  `yaml:"child_id"`
-	HasChild bool `yaml:"has_child"`
+	ChildID  int    `yaml:"child_id"`
+	HasChild bool   `yaml:"has_child"`
+	Lane     string `yaml:"lane,omitempty"` // swimlane of the task, if any
 }
 
 func (bb BoardBuffer) GetBoardBuff() Board { return bb.BoardBuff }
@@ -255,10 +266,230 @@ func (tree *BoardTree) RemoveChildBoard(b *Board) (Board, error) {
 	return Board{}, errors.New("child board not found")
 }
 
+// Boards returns every board in the tree in depth-first order. Each
+// root board is followed by the boards referenced by its tasks, in
+// column and task order.
+func (tree *BoardTree) Boards() []*Board {
+	var boards []*Board
+	var walk func(b *Board)
+	walk = func(b *Board) {
+		boards = append(boards, b)
+		for _, col := range b.Columns {
+			for _, task := range col.Tasks {
+				if !task.HasChild {
+					continue
+				}
+				child, err := tree.GetBoard(task.ChildID)
+				if err != nil {
+					continue
+				}
+				walk(child)
+			}
+		}
+	}
+	for _, b := range tree.RootBoards {
+		walk(b)
+	}
+	return boards
+}
+
+// GetParentBoard returns the board that contains the task referencing
+// the board with the given id. Root boards have no parent board.
+func (tree *BoardTree) GetParentBoard(id int) (*Board, error) {
+	for _, board := range tree.RootBoards {
+		for _, childID := range board.Children {
+			if childID == id {
+				return board, nil
+			}
+		}
+	}
+	for _, board := range tree.ChildBoards {
+		for _, childID := range board.Children {
+			if childID == id {
+				return board, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("couldn't find parent of board with id = %d", id)
+}
+
+// Path returns the chain of boards from the root board down to the
+// board with the given id.
+func (tree *BoardTree) Path(id int) ([]*Board, error) {
+	board, err := tree.GetBoard(id)
+	if err != nil {
+		return nil, err
+	}
+	path := []*Board{board}
+	// Walk up the parent chain until a board without a parent is found.
+	// The path length is capped by the number of boards to guard against
+	// a cycle in corrupted data.
+	for i := 0; i < len(tree.RootBoards)+len(tree.ChildBoards); i++ {
+		parent, err := tree.GetParentBoard(board.ID)
+		if err != nil {
+			break
+		}
+		path = append([]*Board{parent}, path...)
+		board = parent
+	}
+	return path, nil
+}
+
+// PathString returns the slash separated titles of the boards from the
+// root board down to the board with the given id.
+func (tree *BoardTree) PathString(id int) string {
+	path, err := tree.Path(id)
+	if err != nil {
+		return ""
+	}
+	titles := make([]string, len(path))
+	for i, b := range path {
+		titles[i] = b.Title
+	}
+	return strings.Join(titles, "/")
+}
+
+// IsDescendant reports whether the board with the given id is nested,
+// at any depth, under the board with the given ancestor id.
+func (tree *BoardTree) IsDescendant(ancestorID, id int) bool {
+	path, err := tree.Path(id)
+	if err != nil {
+		return false
+	}
+	for _, b := range path[:len(path)-1] {
+		if b.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+// FindTask finds a board task by its id. It returns the board holding
+// the task along with the task's column and task index.
+func (tree *BoardTree) FindTask(id int) (*Board, int, int, error) {
+	for _, board := range tree.Boards() {
+		for colIdx, col := range board.Columns {
+			for taskIdx, task := range col.Tasks {
+				if task.Task != nil && task.Id == id {
+					return board, colIdx, taskIdx, nil
+				}
+			}
+		}
+	}
+	return nil, 0, 0, fmt.Errorf("couldn't find task with id = %d", id)
+}
+
+// MoveTask moves the task at the given index of the source board column
+// to the given index of the destination board column. If the task
+// references a sub-board, the sub-board moves along with it.
+//
+// A task cannot be moved into its own sub-board or any board nested
+// beneath it.
+func (tree *BoardTree) MoveTask(src *Board, srcCol, idx int, dst *Board, dstCol, dstIdx int) error {
+	if srcCol < 0 || srcCol >= len(src.Columns) {
+		return fmt.Errorf("source column index %d out of range", srcCol)
+	}
+	if dstCol < 0 || dstCol >= len(dst.Columns) {
+		return fmt.Errorf("destination column index %d out of range", dstCol)
+	}
+	task, err := src.Columns[srcCol].GetTask(idx)
+	if err != nil {
+		return err
+	}
+	if task.HasChild && (task.ChildID == dst.ID || tree.IsDescendant(task.ChildID, dst.ID)) {
+		return errors.New("cannot move a task into its own sub-board")
+	}
+
+	moved, err := src.Columns[srcCol].Remove(idx)
+	if err != nil {
+		return err
+	}
+
+	col := &dst.Columns[dstCol]
+	col.InsertTask(moved, dstIdx)
+	if dstIdx < 0 || dstIdx >= len(col.Tasks) {
+		dstIdx = len(col.Tasks) - 1
+	}
+	col.Reprioritize(dstIdx)
+
+	// Transfer the sub-board to the destination board.
+	if moved.HasChild && src != dst {
+		src.RemoveChild(moved.ChildID)
+		dst.AddChild(moved.ChildID)
+	}
+
+	// Tasks are stored by value, so moving one shifts the tasks around
+	// it. Re-establish the links from sub-boards to their parent tasks.
+	tree.linkParentTasks(src)
+	if src != dst {
+		tree.linkParentTasks(dst)
+	}
+	return nil
+}
+
+// ReorderTask moves the task at the given index of a column of the
+// board to another index of the same column.
+func (tree *BoardTree) ReorderTask(b *Board, colIdx, from, to int) error {
+	if colIdx < 0 || colIdx >= len(b.Columns) {
+		return fmt.Errorf("column index %d out of range", colIdx)
+	}
+	if err := b.Columns[colIdx].Move(from, to); err != nil {
+		return err
+	}
+	// Reordering shifts the tasks between the two indexes.
+	tree.linkParentTasks(b)
+	return nil
+}
+
+// linkParentTasks points every sub-board referenced by a task of the
+// given board back at that task.
+func (tree *BoardTree) linkParentTasks(b *Board) {
+	for i := range b.Columns {
+		for j := range b.Columns[i].Tasks {
+			task := &b.Columns[i].Tasks[j]
+			if !task.HasChild {
+				continue
+			}
+			child, err := tree.GetBoard(task.ChildID)
+			if err != nil {
+				continue
+			}
+			child.SetParentTask(task)
+		}
+	}
+}
+
 func (tree BoardTree) GetCurrentBoardID() int { return tree.CurrentBoardID }
 
 func (tree *BoardTree) SetCurrentBoardID(id int) { tree.CurrentBoardID = id }
 
+// SetCollapsed records whether the tree view node with the given key is
+// collapsed.
+func (tree *BoardTree) SetCollapsed(key string, collapsed bool) {
+	for i, k := range tree.Collapsed {
+		if k == key {
+			if !collapsed {
+				tree.Collapsed = append(tree.Collapsed[:i], tree.Collapsed[i+1:]...)
+			}
+			return
+		}
+	}
+	if collapsed {
+		tree.Collapsed = append(tree.Collapsed, key)
+	}
+}
+
+// IsCollapsed reports whether the tree view node with the given key is
+// collapsed.
+func (tree *BoardTree) IsCollapsed(key string) bool {
+	for _, k := range tree.Collapsed {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // NewBoard creates and returns a default board. A default board
 // consists of three empty columns: TODO, Working On, and Done.
 func (tree *BoardTree) NewBoard(title string) *Board {
@@ -304,6 +535,7 @@ func (b *Board) DeepCopy(parentTask *BoardTask, tree *BoardTree, childBoards []*
 		ID:         tree.GetBoardCtr(), // Assign newly generated ID
 		Title:      b.Title,            // Copy the title
 		ParentTask: parentTask,
+		Lanes:      append([]string(nil), b.Lanes...),
 	}
 
 	// Deep copy columns
@@ -320,7 +552,11 @@ func (b *Board) DeepCopy(parentTask *BoardTask, tree *BoardTree, childBoards []*
 
 func (c *BoardColumn) DeepCopy(tree *BoardTree, parentBoard *Board, childBoards []*Board) (BoardColumn, error) {
 	newColmun := BoardColumn{
-		Title: c.Title,
+		Title:       c.Title,
+		Prioritizer: c.Prioritizer,
+		Collapsed:   c.Collapsed,
+		WIPLimit:    c.WIPLimit,
+		DoneColumn:  c.DoneColumn,
 	}
 
 	for _, task := range c.Tasks {
@@ -344,11 +580,15 @@ func (t *BoardTask) DeepCopy(tree *BoardTree, parentBoard *Board, childBoards []
 		Started:     t.Started,
 		Finished:    t.Finished,
 		Priority:    t.Priority,
+		Due:         t.Due,
+		Blocked:     t.Blocked,
+		Checklist:   append([]ChecklistItem(nil), t.Checklist...),
 	}
 
 	newBoardTask := BoardTask{
 		Task:     newTask,
 		HasChild: t.HasChild,
+		Lane:     t.Lane,
 	}
 
 	if t.HasChild {
@@ -422,6 +662,31 @@ func (b *Board) RemoveColumn(index int) (BoardColumn, error) {
 	return cpy, nil
 }
 
+// GetLanes returns the swimlanes of the board, in display order.
+func (b Board) GetLanes() []string { return b.Lanes }
+
+// SetLanes sets the swimlanes of the board. Tasks keep their lane even
+// if it is removed, and are shown outside of the lanes.
+func (b *Board) SetLanes(lanes []string) { b.Lanes = lanes }
+
+// LaneIndex returns the index of the named lane, or -1 if the board has
+// no such lane.
+func (b Board) LaneIndex(name string) int {
+	for i, lane := range b.Lanes {
+		if lane == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddLane appends a lane to the board unless it has a lane of that name.
+func (b *Board) AddLane(name string) {
+	if b.LaneIndex(name) < 0 {
+		b.Lanes = append(b.Lanes, name)
+	}
+}
+
 func (b Board) GetChildren() []int { return b.Children }
 
 func (b *Board) AddChild(id int) { b.InsertChild(id, -1) }
@@ -456,6 +721,38 @@ func (bc BoardColumn) GetTitle() string { return bc.Title }
 // SetTitle sets the title of the column.
 func (bc *BoardColumn) SetTitle(t string) { bc.Title = t }
 
+// GetCollapsed reports whether the column is collapsed.
+func (bc BoardColumn) GetCollapsed() bool { return bc.Collapsed }
+
+// SetCollapsed collapses or expands the column.
+func (bc *BoardColumn) SetCollapsed(collapsed bool) { bc.Collapsed = collapsed }
+
+// GetWIPLimit returns the maximum number of tasks in the column, or zero
+// if there is no limit.
+func (bc BoardColumn) GetWIPLimit() int { return bc.WIPLimit }
+
+// SetWIPLimit sets the maximum number of tasks in the column. Zero
+// removes the limit.
+func (bc *BoardColumn) SetWIPLimit(limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid WIP limit %d", limit)
+	}
+	bc.WIPLimit = limit
+	return nil
+}
+
+// AtWIPLimit reports whether adding a task to the column would exceed
+// its WIP limit.
+func (bc BoardColumn) AtWIPLimit() bool {
+	return bc.WIPLimit > 0 && len(bc.Tasks) >= bc.WIPLimit
+}
+
+// IsDoneColumn reports whether tasks entering the column are done.
+func (bc BoardColumn) IsDoneColumn() bool { return bc.DoneColumn }
+
+// SetDoneColumn sets whether tasks entering the column are done.
+func (bc *BoardColumn) SetDoneColumn(done bool) { bc.DoneColumn = done }
+
 // GetTask returns the task at the given index.
 func (bc BoardColumn) GetTask(index int) (*BoardTask, error) {
 	// Ensure index is in the correct range.
@@ -468,41 +765,74 @@ func (bc BoardColumn) GetTask(index int) (*BoardTask, error) {
 // GetTasks returns the list of tasks.
 func (bc BoardColumn) GetTasks() []BoardTask { return bc.Tasks }
 
-// UpdatePriorities updates the priorities of tasks from the given
-// start index.
-func (bc *BoardColumn) UpdatePriorities(start int) error {
-	if err := bc.Bounds(start); err != nil {
-		return fmt.Errorf("Failed to update task priorities: %v\n", err)
+// Reprioritize assigns the task at the given index a priority between
+// the priorities of its neighbours. The whole column is only renumbered
+// when there is no gap left between the neighbours. Unless the column is
+// ordered manually, the task is given a priority after all others.
+func (bc *BoardColumn) Reprioritize(index int) error {
+	if err := bc.Bounds(index); err != nil {
+		return fmt.Errorf("failed to reprioritize task: %v", err)
 	}
+	if !isManual(bc.Prioritizer) {
+		// The order of the column doesn't follow the priorities, so the
+		// task is placed last in the manual order.
+		bc.Tasks[index].SetPriority(nextRank(func(i int) int { return bc.Tasks[i].Priority }, len(bc.Tasks), index))
+		return nil
+	}
+	prev, next := noRank, noRank
+	if index > 0 {
+		prev = bc.Tasks[index-1].Priority
+	}
+	if index < len(bc.Tasks)-1 {
+		next = bc.Tasks[index+1].Priority
+	}
+	p, ok := rankBetween(prev, next)
+	if !ok {
+		bc.Rebalance()
+		return nil
+	}
+	bc.Tasks[index].SetPriority(p)
+	return nil
+}
 
-	var wg sync.WaitGroup
-
-	for i := start; i < len(bc.Tasks); i++ {
-		// Increment the WaitGroup counter
-		wg.Add(1)
-
-		go func(i int) {
-			// Decrement the WaitGroup counter when the goroutine completes
-			defer wg.Done()
+// Rebalance renumbers the priorities of all tasks, leaving a gap of
+// [PriorityStep] between neighbouring tasks.
+func (bc *BoardColumn) Rebalance() {
+	for i := range bc.Tasks {
+		bc.Tasks[i].SetPriority(i * PriorityStep)
+	}
+}
 
-			// Update the task's priority
-			bc.Tasks[i].SetPriority(i)
-		}(i)
+// IndexOf returns the index of the given task in the column, or -1 if
+// the column doesn't contain it.
+func (bc BoardColumn) IndexOf(task *Task) int {
+	for i := range bc.Tasks {
+		if bc.Tasks[i].Task == task {
+			return i
+		}
 	}
+	return -1
+}
 
-	// Wait for all goroutines to complete
-	wg.Wait()
-	return nil
+// PriorityIndex returns the index at which a task with the given
+// priority belongs in the column, that is, in front of the first task
+// whose priority isn't more important.
+func (bc BoardColumn) PriorityIndex(p int) int {
+	for i, task := range bc.Tasks {
+		if task.Priority >= p {
+			return i
+		}
+	}
+	return len(bc.Tasks)
 }
 
 // Add appends a task to the column.
 //
 // Important Considerations:
 //
-//  1. Update Priority: The addition of a task can affect the
-//     priorities of the remaining tasks. Calling [UpdatePriorities]
-//     post-removal should be done to update task priorities
-//     accordingly.
+//  1. Update Priority: The added task needs a priority that places it
+//     between its neighbours. Calling [Reprioritize] post-add should be
+//     done to update the task priority accordingly.
 //
 // Note: The priority updating of the tasks in the column is assumed to
 // be handled outside this function, and should be addressed post-add
@@ -512,6 +842,12 @@ func (bc *BoardColumn) Add(task *BoardTask) error {
 }
 
 func (bc *BoardColumn) InsertTask(task *BoardTask, index int) error {
+	// Tasks entering a done column are done.
+	if bc.DoneColumn && task.Task != nil && !task.Done {
+		task.SetDone(true)
+		task.SetFinished(time.Now())
+	}
+
 	// If index is out of range, then append task to the slice.
 	if err := bc.Bounds(index); err != nil {
 		bc.Tasks = append(bc.Tasks, *task)
@@ -532,13 +868,13 @@ func (bc *BoardColumn) InsertTask(task *BoardTask, index int) error {
 //
 //  1. Buffering. This function returns the removed task, which should
 //     be buffered for potential future reinsertion.
-//  2. Update Priority: The removal of a task can affect the
-//     priorities of the remaining tasks. Calling a sort function
-//     post-removal should be done to update task priorities accordingly.
 //
-// Note: The buffering of the removed task and priority updating is
-// assumed to be handled outside this function, and they should be
-// addressed post-removal operation.
+// The priorities of the remaining tasks stay in order, so they don't
+// need to be updated.
+//
+// Note: The buffering of the removed task is assumed to be handled
+// outside this function, and should be addressed post-removal
+// operation.
 func (bc *BoardColumn) Remove(index int) (*BoardTask, error) {
 	// Ensure index is in the correct range.
 	if err := bc.Bounds(index); err != nil {
@@ -554,6 +890,28 @@ func (bc *BoardColumn) Remove(index int) (*BoardTask, error) {
 	return &cpy, nil
 }
 
+// Move moves the task at the given index to the target index and
+// updates the priorities of the tasks affected by the move. Tasks are
+// only moved while ordered manually, since any other prioritizer would
+// undo the move.
+func (bc *BoardColumn) Move(from, to int) error {
+	if !isManual(bc.Prioritizer) {
+		return fmt.Errorf("ordered by %s, not manually", bc.Prioritizer)
+	}
+	if err := bc.Bounds(from); err != nil {
+		return fmt.Errorf("failed to move task: %v", err)
+	}
+	if err := bc.Bounds(to); err != nil {
+		return fmt.Errorf("failed to move task: %v", err)
+	}
+	task, err := bc.Remove(from)
+	if err != nil {
+		return err
+	}
+	bc.InsertTask(task, to)
+	return bc.Reprioritize(to)
+}
+
 // Bounds checks if an index is within range.
 func (bc BoardColumn) Bounds(index int) error {
 	if index < 0 || index >= len(bc.Tasks) {
@@ -573,3 +931,10 @@ func (bt *BoardTask) SetChildID(id int) { bt.ChildID = id }
 func (bt BoardTask) GetHasChild() bool { return bt.HasChild }
 
 func (bt *BoardTask) SetHasChild(b bool) { bt.HasChild = b }
+
+// GetLane returns the swimlane of the task.
+func (bt BoardTask) GetLane() string { return bt.Lane }
+
+// SetLane sets the swimlane of the task. An empty name moves the task
+// out of every lane.
+func (bt *BoardTask) SetLane(name string) { bt.Lane = name }
@@ -128,7 +128,7 @@ func ExampleGetTasks() {
 	// Column Task ID: 2
 }
 
-func ExampleUpdatePriorities_BoardColumn() {
+func ExampleBoardColumn_Rebalance() {
 	t1 := &Task{Name: "code", Priority: 0}
 	t2 := &Task{Name: "read", Priority: 1}
 	t3 := &Task{Name: "eat", Priority: 2}
@@ -141,7 +141,7 @@ func ExampleUpdatePriorities_BoardColumn() {
 	bc := BoardColumn{Tasks: []BoardTask{task3, task1, task2}}
 
 	// Update the tasks priority
-	bc.UpdatePriorities(0)
+	bc.Rebalance()
 
 	for _, t := range bc.Tasks {
 		fmt.Printf("Task: %6q  Priority: %d\n", t.Name, t.Priority)
@@ -149,8 +149,8 @@ func ExampleUpdatePriorities_BoardColumn() {
 
 	// Output:
 	// Task:  "eat"  Priority: 0
-	// Task: "code"  Priority: 1
-	// Task: "read"  Priority: 2
+	// Task: "code"  Priority: 1024
+	// Task: "read"  Priority: 2048
 }
 
 func ExampleAdd_BoardColumn() {
@@ -224,6 +224,33 @@ func ExampleInsertTask_noTasks() {
 	// Task: "buy groceries"  Priority: 0
 }
 
+func ExampleBoardColumn_InsertTask_doneColumn() {
+	bc := BoardColumn{DoneColumn: true}
+	task := BoardTask{Task: &Task{Name: "ship"}}
+	bc.InsertTask(&task, 0)
+
+	fmt.Println(bc.Tasks[0].Done, !bc.Tasks[0].Finished.IsZero())
+
+	// Output:
+	// true true
+}
+
+func ExampleBoardColumn_AtWIPLimit() {
+	bc := BoardColumn{Tasks: []BoardTask{{Task: &Task{Name: "code"}}}}
+	fmt.Println(bc.AtWIPLimit())
+	bc.SetWIPLimit(2)
+	fmt.Println(bc.AtWIPLimit())
+	bc.Add(&BoardTask{Task: &Task{Name: "read"}})
+	fmt.Println(bc.AtWIPLimit())
+	fmt.Println(bc.SetWIPLimit(-1))
+
+	// Output:
+	// false
+	// false
+	// true
+	// invalid WIP limit -1
+}
+
 func ExampleBounds_BoardColumn() {
 	task1 := BoardTask{Task: &Task{Name: "code"}}
 	bc := BoardColumn{Tasks: []BoardTask{task1}}
@@ -261,3 +288,139 @@ func ExampleSetChild() {
 	// Output:
 	// Board Task Child ID: 1
 }
+
+func ExampleBoardTree_MoveTask() {
+	tree := new(BoardTree)
+	root := tree.NewBoard("Project")
+	tree.AddRoot(root)
+	other := tree.NewBoard("Other")
+	tree.AddRoot(other)
+
+	// Give the root board a task that references a sub-board.
+	child := tree.NewBoard("Build")
+	tree.AddChildBoard(child)
+	root.AddChild(child.ID)
+	root.Columns[0].Add(&BoardTask{Task: &Task{Id: 1, Name: "build"}, ChildID: child.ID, HasChild: true})
+
+	if err := tree.MoveTask(root, 0, 0, other, 1, 0); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Project children:", root.Children)
+	fmt.Println("Other children:", other.Children)
+	fmt.Println("Task:", other.Columns[1].Tasks[0].Name)
+	fmt.Println("Parent task:", child.ParentTask.Name)
+	fmt.Println("Path:", tree.PathString(child.ID))
+
+	// A task cannot be moved into its own sub-board.
+	fmt.Println(tree.MoveTask(other, 1, 0, child, 0, 0))
+
+	// Output:
+	// Project children: []
+	// Other children: [3]
+	// Task: build
+	// Parent task: build
+	// Path: Other/Build
+	// cannot move a task into its own sub-board
+}
+
+func ExampleBoardTree_ReorderTask() {
+	tree := new(BoardTree)
+	root := tree.NewBoard("Project")
+	tree.AddRoot(root)
+	child := tree.NewBoard("Build")
+	tree.AddChildBoard(child)
+	root.AddChild(child.ID)
+	root.Columns[0].Add(&BoardTask{Task: &Task{Id: 1, Name: "build"}, ChildID: child.ID, HasChild: true})
+	root.Columns[0].Add(&BoardTask{Task: &Task{Id: 2, Name: "test"}})
+	tree.linkParentTasks(root)
+
+	if err := tree.ReorderTask(root, 0, 0, 1); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Task:", root.Columns[0].Tasks[1].Name)
+	fmt.Println("Parent task:", child.ParentTask.Name)
+
+	// Output:
+	// Task: build
+	// Parent task: build
+}
+
+func ExampleBoardTree_Boards() {
+	tree := new(BoardTree)
+	root := tree.NewBoard("Project")
+	tree.AddRoot(root)
+	child := tree.NewBoard("Build")
+	tree.AddChildBoard(child)
+	root.AddChild(child.ID)
+	root.Columns[1].Add(&BoardTask{Task: &Task{Id: 1, Name: "build"}, ChildID: child.ID, HasChild: true})
+	tree.AddRoot(tree.NewBoard("Other"))
+
+	for _, b := range tree.Boards() {
+		fmt.Println(tree.PathString(b.ID))
+	}
+
+	// Output:
+	// Project
+	// Project/Build
+	// Other
+}
+
+func ExampleBoardColumn_PriorityIndex() {
+	bc := BoardColumn{Tasks: []BoardTask{
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "read", Priority: 1}},
+	}}
+	fmt.Println(bc.PriorityIndex(1))
+	fmt.Println(bc.PriorityIndex(5))
+
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleBoardColumn_Move() {
+	bc := BoardColumn{Tasks: []BoardTask{
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "read", Priority: 1}},
+		{Task: &Task{Name: "eat", Priority: 2}},
+	}}
+	bc.Move(0, 1)
+	fmt.Println(bc.Move(0, 3))
+
+	for _, t := range bc.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// failed to move task: index 3 out of range.
+	// Task: "read"  Priority: 0
+	// Task: "code"  Priority: 1024
+	// Task: "eat"  Priority: 2048
+}
+
+func ExampleBoardTree_SetCollapsed() {
+	tree := new(BoardTree)
+	tree.SetCollapsed("board:1", true)
+	tree.SetCollapsed("board:2", true)
+	tree.SetCollapsed("board:1", true)
+	tree.SetCollapsed("board:2", false)
+	fmt.Println(tree.Collapsed)
+	fmt.Println(tree.IsCollapsed("board:1"), tree.IsCollapsed("board:2"))
+
+	// Output:
+	// [board:1]
+	// true false
+}
+
+func ExampleBoard_AddLane() {
+	b := new(Board)
+	b.AddLane("Bugs")
+	b.AddLane("Features")
+	b.AddLane("Bugs")
+	fmt.Println(b.GetLanes())
+	fmt.Println(b.LaneIndex("Features"), b.LaneIndex("Docs"))
+
+	// Output:
+	// [Bugs Features]
+	// 1 -1
+}
@@ -0,0 +1,42 @@
+package tasks
+
+import "fmt"
+
+// A ChecklistItem is a step of a task, lighter than a task on a
+// sub-board.
+type ChecklistItem struct {
+	Name string `yaml:"name"`
+	Done bool   `yaml:"done"`
+}
+
+// GetChecklist returns the checklist items of the task.
+func (t Task) GetChecklist() []ChecklistItem { return t.Checklist }
+
+// SetChecklist replaces the checklist items of the task.
+func (t *Task) SetChecklist(items []ChecklistItem) { t.Checklist = items }
+
+// AddChecklistItem appends an item that isn't done to the checklist.
+func (t *Task) AddChecklistItem(name string) {
+	t.Checklist = append(t.Checklist, ChecklistItem{Name: name})
+}
+
+// ToggleChecklistItem marks the checklist item at the given index done,
+// or not done if it is.
+func (t *Task) ToggleChecklistItem(index int) error {
+	if index < 0 || index >= len(t.Checklist) {
+		return fmt.Errorf("no checklist item %d", index+1)
+	}
+	t.Checklist[index].Done = !t.Checklist[index].Done
+	return nil
+}
+
+// ChecklistCount returns the number of done checklist items and the
+// number of items.
+func (t Task) ChecklistCount() (done, total int) {
+	for _, item := range t.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(t.Checklist)
+}
@@ -0,0 +1,17 @@
+package tasks
+
+import "fmt"
+
+func ExampleTask_ToggleChecklistItem() {
+	task := &Task{Name: "release"}
+	task.AddChecklistItem("tag")
+	task.AddChecklistItem("build")
+	task.AddChecklistItem("announce")
+	task.ToggleChecklistItem(1)
+	fmt.Println(task.ChecklistCount())
+	fmt.Println(task.ToggleChecklistItem(3))
+
+	// Output:
+	// 1 3
+	// no checklist item 4
+}
@@ -0,0 +1,39 @@
+package tasks
+
+// CountBoard returns the number of tasks on a board and its sub-boards,
+// at any depth, and the number of those sub-boards.
+func (tree *BoardTree) CountBoard(b *Board) (tasks, boards int) {
+	for _, col := range b.Columns {
+		t, bs := tree.CountColumn(col)
+		tasks += t
+		boards += bs
+	}
+	return tasks, boards
+}
+
+// CountColumn returns the number of tasks in a column and on the
+// sub-boards of its tasks, at any depth, and the number of those
+// sub-boards.
+func (tree *BoardTree) CountColumn(col BoardColumn) (tasks, boards int) {
+	for _, task := range col.Tasks {
+		t, bs := tree.CountTask(task)
+		tasks += t
+		boards += bs
+	}
+	return tasks, boards
+}
+
+// CountTask returns the number of tasks removed along with a task,
+// including itself and the tasks on its sub-board at any depth, and the
+// number of sub-boards.
+func (tree *BoardTree) CountTask(task BoardTask) (tasks, boards int) {
+	if !task.HasChild {
+		return 1, 0
+	}
+	child, err := tree.GetBoard(task.ChildID)
+	if err != nil {
+		return 1, 0
+	}
+	tasks, boards = tree.CountBoard(child)
+	return tasks + 1, boards + 1
+}
@@ -0,0 +1,34 @@
+package tasks
+
+import "fmt"
+
+func ExampleBoardTree_CountBoard() {
+	tree := new(BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+	auth := tree.NewBoard("Auth")
+	tree.AddChildBoard(auth)
+
+	project.Columns[0].Tasks = []BoardTask{
+		{Task: &Task{Name: "design"}},
+		{Task: &Task{Name: "api"}, ChildID: api.ID, HasChild: true},
+	}
+	api.Columns[1].Tasks = []BoardTask{
+		{Task: &Task{Name: "auth"}, ChildID: auth.ID, HasChild: true},
+	}
+	auth.Columns[0].Tasks = []BoardTask{
+		{Task: &Task{Name: "login"}},
+		{Task: &Task{Name: "logout"}},
+	}
+
+	fmt.Println(tree.CountBoard(project))
+	fmt.Println(tree.CountColumn(api.Columns[1]))
+	fmt.Println(tree.CountTask(project.Columns[0].Tasks[0]))
+
+	// Output:
+	// 5 2
+	// 3 1
+	// 1 0
+}
@@ -0,0 +1,63 @@
+package tasks
+
+import "fmt"
+
+// DeleteBoard removes a root board along with the boards beneath it, at
+// any depth. Sub-boards are deleted along with their parent task.
+func (tree *BoardTree) DeleteBoard(b *Board) error {
+	if _, err := tree.RemoveRoot(b); err != nil {
+		return fmt.Errorf("board %d is not a root board", b.ID)
+	}
+	for _, id := range b.Children {
+		tree.removeSubBoard(id)
+	}
+	return nil
+}
+
+// DeleteColumn removes a column of the board along with the sub-boards
+// of its tasks, at any depth.
+func (tree *BoardTree) DeleteColumn(b *Board, col int) error {
+	removed, err := b.RemoveColumn(col)
+	if err != nil {
+		return err
+	}
+	for _, task := range removed.Tasks {
+		if task.HasChild {
+			b.RemoveChild(task.ChildID)
+			tree.removeSubBoard(task.ChildID)
+		}
+	}
+	return nil
+}
+
+// DeleteTask removes a task of the given board column along with its
+// sub-board, at any depth.
+func (tree *BoardTree) DeleteTask(b *Board, col, idx int) error {
+	if col < 0 || col >= len(b.Columns) {
+		return fmt.Errorf("column index %d out of range", col)
+	}
+	removed, err := b.Columns[col].Remove(idx)
+	if err != nil {
+		return err
+	}
+	if removed.HasChild {
+		b.RemoveChild(removed.ChildID)
+		tree.removeSubBoard(removed.ChildID)
+	}
+	// Removing a task shifts the tasks after it.
+	tree.linkParentTasks(b)
+	return nil
+}
+
+// removeSubBoard removes the sub-board with the given id and the boards
+// beneath it from the tree.
+func (tree *BoardTree) removeSubBoard(id int) {
+	b, err := tree.GetBoard(id)
+	if err != nil {
+		return
+	}
+	for _, child := range b.Children {
+		tree.removeSubBoard(child)
+	}
+	tree.RemoveChildBoard(b)
+}
@@ -0,0 +1,74 @@
+package tasks
+
+import "fmt"
+
+func ExampleBoardTree_DeleteTask() {
+	tree := new(BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+	auth := tree.NewBoard("Auth")
+	tree.AddChildBoard(auth)
+
+	project.Columns[0].Tasks = []BoardTask{
+		{Task: &Task{Name: "api"}, ChildID: api.ID, HasChild: true},
+		{Task: &Task{Name: "docs"}},
+	}
+	project.AddChild(api.ID)
+	api.Columns[0].Tasks = []BoardTask{
+		{Task: &Task{Name: "auth"}, ChildID: auth.ID, HasChild: true},
+	}
+	api.AddChild(auth.ID)
+
+	if err := tree.DeleteTask(project, 0, 0); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(len(project.Columns[0].Tasks), len(project.Children), len(tree.ChildBoards))
+
+	// Output:
+	// 1 0 0
+}
+
+func ExampleBoardTree_DeleteColumn() {
+	tree := new(BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+
+	project.Columns[1].Tasks = []BoardTask{
+		{Task: &Task{Name: "api"}, ChildID: api.ID, HasChild: true},
+	}
+	project.AddChild(api.ID)
+
+	if err := tree.DeleteColumn(project, 1); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(len(project.Columns), len(project.Children), len(tree.ChildBoards))
+
+	// Output:
+	// 2 0 0
+}
+
+func ExampleBoardTree_DeleteBoard() {
+	tree := new(BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+
+	project.Columns[0].Tasks = []BoardTask{
+		{Task: &Task{Name: "api"}, ChildID: api.ID, HasChild: true},
+	}
+	project.AddChild(api.ID)
+
+	fmt.Println(tree.DeleteBoard(api))
+	fmt.Println(tree.DeleteBoard(project))
+	fmt.Println(len(tree.RootBoards), len(tree.ChildBoards))
+
+	// Output:
+	// board 2 is not a root board
+	// <nil>
+	// 0 0
+}
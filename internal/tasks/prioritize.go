@@ -0,0 +1,285 @@
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// A Prioritizer determines the order of tasks in a todo list or board
+// column.
+type Prioritizer interface {
+	// Name returns the name the prioritizer is selected and persisted by.
+	Name() string
+	// Less reports whether task a is more important than task b.
+	Less(a, b *Task) bool
+}
+
+// Names of the built-in prioritizers.
+const (
+	Manual   = "manual"
+	ByDue    = "due"
+	ByAge    = "age"
+	Weighted = "weighted"
+)
+
+// PriorityStep is the gap left between the priorities of neighbouring
+// tasks. The gap allows a task to be placed between two others without
+// renumbering the rest of the list or column.
+const PriorityStep = 1 << 10
+
+// noRank marks a missing neighbour when ranking a task.
+const noRank = math.MinInt
+
+// rankBetween returns a priority that orders a task between the given
+// previous and next priorities, either of which may be noRank. It
+// returns false if there is no gap left between them.
+func rankBetween(prev, next int) (int, bool) {
+	switch {
+	case prev == noRank && next == noRank:
+		return 0, true
+	case prev == noRank:
+		return next - PriorityStep, true
+	case next == noRank:
+		return prev + PriorityStep, true
+	case next-prev > 1:
+		return prev + (next-prev)/2, true
+	}
+	return 0, false
+}
+
+var prioritizers = map[string]Prioritizer{}
+
+func init() {
+	RegisterPrioritizer(ManualPrioritizer{})
+	RegisterPrioritizer(DuePrioritizer{})
+	RegisterPrioritizer(AgePrioritizer{})
+	RegisterPrioritizer(NewWeightedPrioritizer())
+}
+
+// RegisterPrioritizer makes a prioritizer available by its name,
+// replacing any prioritizer previously registered with the same name.
+func RegisterPrioritizer(p Prioritizer) { prioritizers[p.Name()] = p }
+
+// GetPrioritizer returns the prioritizer registered with the given
+// name. An empty name selects the manual prioritizer.
+func GetPrioritizer(name string) (Prioritizer, error) {
+	if name == "" {
+		name = Manual
+	}
+	p, ok := prioritizers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown prioritizer %q", name)
+	}
+	return p, nil
+}
+
+// Prioritizers returns the sorted names of all registered prioritizers.
+func Prioritizers() []string {
+	names := make([]string, 0, len(prioritizers))
+	for name := range prioritizers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ManualPrioritizer orders tasks by their priority field, which is set
+// by the user when adding and reordering tasks. Other prioritizers only
+// change the order of the tasks, not their priorities, so switching
+// back to the manual prioritizer restores the order set by the user.
+type ManualPrioritizer struct{}
+
+func (ManualPrioritizer) Name() string { return Manual }
+
+func (ManualPrioritizer) Less(a, b *Task) bool { return a.Priority < b.Priority }
+
+// DuePrioritizer orders tasks by their due date, earliest first. Tasks
+// without a due date come last.
+type DuePrioritizer struct{}
+
+func (DuePrioritizer) Name() string { return ByDue }
+
+func (DuePrioritizer) Less(a, b *Task) bool {
+	switch {
+	case a.Due.IsZero():
+		return false
+	case b.Due.IsZero():
+		return true
+	}
+	return a.Due.Before(b.Due)
+}
+
+// AgePrioritizer orders tasks by the date they were started, oldest
+// first.
+type AgePrioritizer struct{}
+
+func (AgePrioritizer) Name() string { return ByAge }
+
+func (AgePrioritizer) Less(a, b *Task) bool { return a.Started.Before(b.Started) }
+
+// WeightedPrioritizer orders tasks by a score combining the days left
+// until the task is due, its current priority and whether it is
+// blocked. A lower score indicates a more important task.
+type WeightedPrioritizer struct {
+	DueWeight      float64          // weight of each day left until the due date
+	PriorityWeight float64          // weight of each PriorityStep of priority
+	BlockedWeight  float64          // penalty for blocked tasks
+	NoDueDays      float64          // days left assumed for tasks without a due date
+	Now            func() time.Time // current time, used to compute days left
+}
+
+// NewWeightedPrioritizer returns a weighted prioritizer with default
+// weights.
+func NewWeightedPrioritizer() WeightedPrioritizer {
+	return WeightedPrioritizer{
+		DueWeight:      1,
+		PriorityWeight: 1,
+		BlockedWeight:  100,
+		NoDueDays:      30,
+		Now:            time.Now,
+	}
+}
+
+func (WeightedPrioritizer) Name() string { return Weighted }
+
+func (w WeightedPrioritizer) Less(a, b *Task) bool { return w.Score(a) < w.Score(b) }
+
+// Score returns the weighted score of a task.
+func (w WeightedPrioritizer) Score(t *Task) float64 {
+	days := w.NoDueDays
+	if !t.Due.IsZero() {
+		days = t.Due.Sub(w.Now()).Hours() / 24
+	}
+	score := w.DueWeight*days + w.PriorityWeight*float64(t.Priority)/PriorityStep
+	if t.Blocked {
+		score += w.BlockedWeight
+	}
+	return score
+}
+
+// isManual reports whether the prioritizer of the given name orders
+// tasks manually.
+func isManual(name string) bool { return name == "" || name == Manual }
+
+// nextRank returns a priority that orders a task after the tasks of the
+// given priorities, skipping the priority at index skip.
+func nextRank(priorities func(i int) int, n, skip int) int {
+	last := noRank
+	for i := 0; i < n; i++ {
+		if i != skip && (last == noRank || priorities(i) > last) {
+			last = priorities(i)
+		}
+	}
+	p, _ := rankBetween(last, noRank)
+	return p
+}
+
+// spreadRanks renumbers n priorities in their order, leaving a gap of
+// [PriorityStep] between neighbours, without reordering the tasks.
+func spreadRanks(n int, get func(i int) int, set func(i, p int)) {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return get(order[a]) < get(order[b]) })
+	for rank, i := range order {
+		set(i, rank*PriorityStep)
+	}
+}
+
+// SpreadPriorities renumbers the priorities of the tasks in their
+// manual order, leaving a gap of [PriorityStep] between neighbours.
+// Lists saved with consecutive priorities are spread out when loaded,
+// so that the priorities weigh in on the weighted order.
+func (t *TodoList) SpreadPriorities() {
+	spreadRanks(len(t.Tasks),
+		func(i int) int { return t.Tasks[i].Priority },
+		func(i, p int) { t.Tasks[i].SetPriority(p) })
+}
+
+// SpreadPriorities renumbers the priorities of the tasks of every
+// column of every board in their manual order, like
+// [TodoList.SpreadPriorities].
+func (tree *BoardTree) SpreadPriorities() {
+	for _, b := range tree.Boards() {
+		for i := range b.Columns {
+			col := &b.Columns[i]
+			spreadRanks(len(col.Tasks),
+				func(i int) int { return col.Tasks[i].Priority },
+				func(i, p int) { col.Tasks[i].SetPriority(p) })
+		}
+	}
+}
+
+// GetPrioritizer returns the name of the prioritizer the todo list is
+// ordered by.
+func (t *TodoList) GetPrioritizer() string { return t.Prioritizer }
+
+// SetPrioritizer selects the prioritizer the todo list is ordered by.
+func (t *TodoList) SetPrioritizer(name string) error {
+	if _, err := GetPrioritizer(name); err != nil {
+		return err
+	}
+	t.Prioritizer = name
+	return nil
+}
+
+// Prioritize orders the tasks of the todo list with its prioritizer.
+// The priorities of the tasks are left alone. Unless ordered manually,
+// completed tasks are placed after pending ones.
+func (t *TodoList) Prioritize() error {
+	p, err := GetPrioritizer(t.Prioritizer)
+	if err != nil {
+		return err
+	}
+	manual := p.Name() == Manual
+	sort.SliceStable(t.Tasks, func(i, j int) bool {
+		a, b := t.Tasks[i].Task, t.Tasks[j].Task
+		if a.Done != b.Done && !manual {
+			return !a.Done
+		}
+		return p.Less(a, b)
+	})
+	return nil
+}
+
+// GetPrioritizer returns the name of the prioritizer the column is
+// ordered by.
+func (bc BoardColumn) GetPrioritizer() string { return bc.Prioritizer }
+
+// SetPrioritizer selects the prioritizer the column is ordered by.
+func (bc *BoardColumn) SetPrioritizer(name string) error {
+	if _, err := GetPrioritizer(name); err != nil {
+		return err
+	}
+	bc.Prioritizer = name
+	return nil
+}
+
+// Prioritize orders the tasks of the column with its prioritizer. The
+// priorities of the tasks are left alone.
+func (bc *BoardColumn) Prioritize() error {
+	p, err := GetPrioritizer(bc.Prioritizer)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(bc.Tasks, func(i, j int) bool {
+		return p.Less(bc.Tasks[i].Task, bc.Tasks[j].Task)
+	})
+	return nil
+}
+
+// PrioritizeColumn orders the tasks of a column of the board with its
+// prioritizer, keeping sub-boards linked to their parent tasks.
+func (tree *BoardTree) PrioritizeColumn(b *Board, colIdx int) error {
+	if colIdx < 0 || colIdx >= len(b.Columns) {
+		return fmt.Errorf("column index %d out of range", colIdx)
+	}
+	if err := b.Columns[colIdx].Prioritize(); err != nil {
+		return err
+	}
+	tree.linkParentTasks(b)
+	return nil
+}
@@ -0,0 +1,133 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+)
+
+func ExamplePrioritizers() {
+	fmt.Println(Prioritizers())
+
+	// Output:
+	// [age due manual weighted]
+}
+
+func ExampleBoardColumn_Prioritize() {
+	now := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	bc := BoardColumn{Tasks: []BoardTask{
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "read", Priority: 1, Due: now.AddDate(0, 0, 7)}},
+		{Task: &Task{Name: "eat", Priority: 2, Due: now.AddDate(0, 0, 1)}},
+	}}
+	bc.SetPrioritizer(ByDue)
+	bc.Prioritize()
+
+	for _, t := range bc.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+	fmt.Println(bc.Move(0, 1))
+
+	// Switching back restores the manual order.
+	bc.SetPrioritizer(Manual)
+	bc.Prioritize()
+	for _, t := range bc.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// Task: "eat"  Priority: 2
+	// Task: "read"  Priority: 1
+	// Task: "code"  Priority: 0
+	// ordered by due, not manually
+	// Task: "code"  Priority: 0
+	// Task: "read"  Priority: 1
+	// Task: "eat"  Priority: 2
+}
+
+func ExampleTodoList_Prioritize() {
+	now := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	list := TodoList{Tasks: []TodoTask{
+		{Task: &Task{Name: "code", Priority: 0, Started: now, Done: true}},
+		{Task: &Task{Name: "read", Priority: 1024, Started: now.AddDate(0, 0, -1)}},
+		{Task: &Task{Name: "eat", Priority: 2048, Started: now.AddDate(0, 0, -2)}},
+	}}
+	list.SetPrioritizer(ByAge)
+	list.Prioritize()
+
+	// A task added to the sorted list goes last in the manual order.
+	list.Add(&TodoTask{Task: &Task{Name: "nap", Started: now}}, 0)
+	list.Reprioritize(0)
+	list.Prioritize()
+
+	for _, t := range list.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// Task: "eat"  Priority: 2048
+	// Task: "read"  Priority: 1024
+	// Task: "nap"  Priority: 3072
+	// Task: "code"  Priority: 0
+}
+
+func ExampleWeightedPrioritizer_Score() {
+	now := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	w := NewWeightedPrioritizer()
+	w.Now = func() time.Time { return now }
+
+	fmt.Println(w.Score(&Task{Priority: PriorityStep, Due: now.AddDate(0, 0, 2)}))
+	fmt.Println(w.Score(&Task{Priority: PriorityStep, Blocked: true}))
+
+	// Output:
+	// 3
+	// 131
+}
+
+func ExampleBoardColumn_SetPrioritizer() {
+	var bc BoardColumn
+	fmt.Println(bc.SetPrioritizer("random"))
+
+	// Output:
+	// unknown prioritizer "random"
+}
+
+func ExampleBoardColumn_Reprioritize() {
+	bc := BoardColumn{Tasks: []BoardTask{
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "read", Priority: PriorityStep}},
+	}}
+
+	// Only the inserted task is given a new priority.
+	bc.InsertTask(&BoardTask{Task: &Task{Name: "eat"}}, 1)
+	bc.Reprioritize(1)
+
+	for _, t := range bc.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// Task: "code"  Priority: 0
+	// Task: "eat"  Priority: 512
+	// Task: "read"  Priority: 1024
+}
+
+func ExampleTodoList_Reprioritize() {
+	list := TodoList{Tasks: []TodoTask{
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "read", Priority: 1}},
+	}}
+
+	// There is no gap left between the neighbours, so the list is
+	// rebalanced.
+	list.Add(&TodoTask{Task: &Task{Name: "eat"}}, 1)
+	list.Reprioritize(1)
+
+	for _, t := range list.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// Task: "code"  Priority: 0
+	// Task: "eat"  Priority: 1024
+	// Task: "read"  Priority: 2048
+}
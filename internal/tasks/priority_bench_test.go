@@ -0,0 +1,85 @@
+package tasks
+
+import (
+	"sync"
+	"testing"
+)
+
+// updatePrioritiesConcurrent is the previous implementation of
+// renumbering priorities, which spawned a goroutine per task. It is
+// kept to benchmark against.
+func updatePrioritiesConcurrent(bc *BoardColumn, start int) {
+	var wg sync.WaitGroup
+	for i := start; i < len(bc.Tasks); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bc.Tasks[i].SetPriority(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func newBenchColumn(n int) *BoardColumn {
+	bc := new(BoardColumn)
+	for i := 0; i < n; i++ {
+		bc.Add(&BoardTask{Task: &Task{Priority: i * PriorityStep}})
+	}
+	return bc
+}
+
+func BenchmarkUpdatePriorities_concurrent(b *testing.B) {
+	bc := newBenchColumn(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		updatePrioritiesConcurrent(bc, 0)
+	}
+}
+
+func BenchmarkRebalance(b *testing.B) {
+	bc := newBenchColumn(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bc.Rebalance()
+	}
+}
+
+// The insert benchmarks add a task to the top of the column, which
+// previously required renumbering every task below it.
+func BenchmarkInsert_concurrent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		bc := newBenchColumn(1000)
+		b.StartTimer()
+		bc.InsertTask(&BoardTask{Task: &Task{}}, 0)
+		updatePrioritiesConcurrent(bc, 0)
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		bc := newBenchColumn(1000)
+		b.StartTimer()
+		bc.InsertTask(&BoardTask{Task: &Task{}}, 0)
+		bc.Reprioritize(0)
+	}
+}
+
+func TestReprioritize(t *testing.T) {
+	bc := newBenchColumn(3)
+	// Repeatedly insert into the same spot until the gap is used up and
+	// the column has to be rebalanced.
+	for i := 0; i < 20; i++ {
+		bc.InsertTask(&BoardTask{Task: &Task{}}, 1)
+		if err := bc.Reprioritize(1); err != nil {
+			t.Fatal(err)
+		}
+		for j := 1; j < len(bc.Tasks); j++ {
+			if bc.Tasks[j-1].Priority >= bc.Tasks[j].Priority {
+				t.Fatalf("insert %d: priorities out of order at %d: %d >= %d",
+					i, j, bc.Tasks[j-1].Priority, bc.Tasks[j].Priority)
+			}
+		}
+	}
+}
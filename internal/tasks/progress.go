@@ -0,0 +1,79 @@
+package tasks
+
+// Progress returns the number of done tasks and the number of tasks on
+// a board. A task with a sub-board counts as the tasks on its sub-board,
+// at any depth, unless the sub-board has none. A task is done if it is
+// in a done column or marked done.
+func (tree *BoardTree) Progress(b *Board) (done, total int) {
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			if task.HasChild {
+				if child, err := tree.GetBoard(task.ChildID); err == nil {
+					if d, t := tree.Progress(child); t > 0 {
+						done += d
+						total += t
+						continue
+					}
+				}
+			}
+			total++
+			if col.DoneColumn || task.Task != nil && task.Done {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// parentTask returns the board, column and index of the task whose
+// sub-board is the board of the given id.
+func (tree *BoardTree) parentTask(id int) (*Board, int, int, bool) {
+	parent, err := tree.GetParentBoard(id)
+	if err != nil {
+		return nil, 0, 0, false
+	}
+	for colIdx, col := range parent.Columns {
+		for taskIdx, task := range col.Tasks {
+			if task.HasChild && task.ChildID == id {
+				return parent, colIdx, taskIdx, true
+			}
+		}
+	}
+	return nil, 0, 0, false
+}
+
+// CompleteParents moves the task whose sub-board is the given board to
+// the first done column of its board once every task on the sub-board
+// is done, and does the same for the boards above. It returns the names
+// of the moved tasks. Tasks on boards without a done column stay put.
+func (tree *BoardTree) CompleteParents(b *Board) []string {
+	var moved []string
+	for {
+		if done, total := tree.Progress(b); total == 0 || done < total {
+			return moved
+		}
+		parent, colIdx, idx, ok := tree.parentTask(b.ID)
+		if !ok {
+			return moved
+		}
+		doneCol := -1
+		for i, col := range parent.Columns {
+			if col.DoneColumn {
+				doneCol = i
+				break
+			}
+		}
+		if doneCol < 0 {
+			return moved
+		}
+		if doneCol != colIdx {
+			task := parent.Columns[colIdx].Tasks[idx]
+			dstIdx := parent.Columns[doneCol].PriorityIndex(task.Priority)
+			if err := tree.MoveTask(parent, colIdx, idx, parent, doneCol, dstIdx); err != nil {
+				return moved
+			}
+			moved = append(moved, task.Name)
+		}
+		b = parent
+	}
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import "fmt"
+
+func ExampleBoardTree_Progress() {
+	tree := new(BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+	project.AddChild(api.ID)
+	api.Columns[2].SetDoneColumn(true)
+
+	project.Columns[0].Tasks = []BoardTask{
+		{Task: &Task{Name: "design", Done: true}},
+		{Task: &Task{Name: "api"}, ChildID: api.ID, HasChild: true},
+	}
+	api.Columns[0].Tasks = []BoardTask{{Task: &Task{Name: "auth"}}}
+	api.Columns[2].Tasks = []BoardTask{{Task: &Task{Name: "routes"}}}
+
+	fmt.Println(tree.Progress(project))
+	fmt.Println(tree.Progress(api))
+
+	// Output:
+	// 2 3
+	// 1 2
+}
+
+func ExampleBoardTree_CompleteParents() {
+	tree := new(BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	project.Columns[2].SetDoneColumn(true)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+	project.AddChild(api.ID)
+	api.Columns[2].SetDoneColumn(true)
+
+	project.Columns[1].Tasks = []BoardTask{
+		{Task: &Task{Name: "api"}, ChildID: api.ID, HasChild: true},
+	}
+	api.Columns[1].Tasks = []BoardTask{{Task: &Task{Name: "auth"}}}
+
+	fmt.Println(tree.CompleteParents(api))
+	tree.MoveTask(api, 1, 0, api, 2, 0)
+	fmt.Println(tree.CompleteParents(api))
+	fmt.Println(len(project.Columns[1].Tasks), project.Columns[2].Tasks[0].Done)
+
+	// Output:
+	// []
+	// [api]
+	// 0 true
+}
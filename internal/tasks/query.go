@@ -0,0 +1,459 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// A Query is a parsed filter expression that tasks are matched against.
+//
+// A query is a space separated list of terms that must all match. Each
+// term has the form field op value, and may be prefixed by "-" to
+// negate it. Values containing spaces can be double quoted. A term
+// without a field matches tasks whose name or description contains the
+// term.
+//
+// Supported fields and operators:
+//
+//	name, desc, column   ":" equals, "~" contains
+//	board                ":" board path with "*" wildcards, "~" contains
+//	done, blocked, core  ":" true or false
+//	child                ":" true or false, whether the task has a sub-board
+//	priority             ":", ":<", ":>", ":<=", ":>=" an integer
+//	started, finished    ":", ":<", ":>", ":<=", ":>=" a date or age
+//	due                  ":", ":<", ":>", ":<=", ":>=" a date or time left
+//
+// Dates are written as YYYY-MM-DD, or "none" to match tasks without the
+// date. Ages are written as a number followed by h, d or w. For example,
+// started:<7d matches tasks started less than seven days ago, and
+// due:<3d matches tasks due within the next three days.
+//
+// Example:
+//
+//	done:false started:<7d board:Project/* name~"api"
+type Query struct {
+	Source string           // query as written by the user
+	Now    func() time.Time // current time, used to evaluate ages
+
+	terms []term
+}
+
+// A Target is a task along with its location, as seen by a query.
+type Target struct {
+	Task     *Task
+	IsCore   bool   // whether the task is a core todo list task
+	HasChild bool   // whether the task references a sub-board
+	Board    string // slash separated board path, empty for todo list tasks
+	Column   string // column title, empty for todo list tasks
+	Lane     string // swimlane, empty for todo list tasks and tasks without one
+}
+
+type term struct {
+	field  string
+	op     string
+	value  string
+	negate bool
+
+	boolVal bool
+	intVal  int
+	dateVal time.Time
+	durVal  time.Duration
+	isDur   bool
+	none    bool
+	pattern *regexp.Regexp
+}
+
+// Query operators.
+const (
+	opEq       = ":"
+	opContains = "~"
+	opLt       = ":<"
+	opGt       = ":>"
+	opLe       = ":<="
+	opGe       = ":>="
+)
+
+// ParseQuery parses a query string.
+func ParseQuery(s string) (*Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{Source: s, Now: time.Now}
+	for _, tok := range tokens {
+		tm, err := parseTerm(tok)
+		if err != nil {
+			return nil, err
+		}
+		q.terms = append(q.terms, tm)
+	}
+	return q, nil
+}
+
+// tokenize splits a query on spaces that aren't inside double quotes.
+// Quotes are kept so that terms can be parsed afterwards.
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, errors.New("unterminated quote in query")
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+// parseTerm parses a single query term.
+func parseTerm(tok string) (term, error) {
+	var tm term
+	if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+		tm.negate = true
+		tok = tok[1:]
+	}
+
+	// Find the end of the field name.
+	i := 0
+	for i < len(tok) && (unicode.IsLetter(rune(tok[i]))) {
+		i++
+	}
+	if i == 0 || i == len(tok) || (tok[i] != ':' && tok[i] != '~') {
+		// A bare word matches the name or description.
+		tm.field = "text"
+		tm.op = opContains
+		tm.value = strings.ToLower(unquote(tok))
+		return tm, nil
+	}
+	tm.field = strings.ToLower(tok[:i])
+	rest := tok[i:]
+	for _, op := range []string{opLe, opGe, opLt, opGt, opEq, opContains} {
+		if strings.HasPrefix(rest, op) {
+			tm.op = op
+			tm.value = unquote(rest[len(op):])
+			break
+		}
+	}
+
+	switch tm.field {
+	case "name", "desc", "description", "column", "lane":
+		if tm.field == "description" {
+			tm.field = "desc"
+		}
+		if tm.op != opEq && tm.op != opContains {
+			return tm, unsupportedOp(tm)
+		}
+		tm.value = strings.ToLower(tm.value)
+	case "board":
+		switch tm.op {
+		case opEq:
+			pattern := regexp.QuoteMeta(strings.ToLower(tm.value))
+			pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+			tm.pattern = regexp.MustCompile("^" + pattern + "$")
+		case opContains:
+			tm.value = strings.ToLower(tm.value)
+		default:
+			return tm, unsupportedOp(tm)
+		}
+	case "done", "blocked", "core", "child":
+		if tm.op != opEq {
+			return tm, unsupportedOp(tm)
+		}
+		b, err := strconv.ParseBool(tm.value)
+		if err != nil {
+			return tm, fmt.Errorf("invalid value %q for %s, expected true or false", tm.value, tm.field)
+		}
+		tm.boolVal = b
+	case "priority":
+		if tm.op == opContains {
+			return tm, unsupportedOp(tm)
+		}
+		n, err := strconv.Atoi(tm.value)
+		if err != nil {
+			return tm, fmt.Errorf("invalid value %q for priority, expected an integer", tm.value)
+		}
+		tm.intVal = n
+	case "started", "finished", "due":
+		if tm.op == opContains {
+			return tm, unsupportedOp(tm)
+		}
+		if err := parseWhen(&tm); err != nil {
+			return tm, err
+		}
+	default:
+		return tm, fmt.Errorf("unknown query field %q", tm.field)
+	}
+	return tm, nil
+}
+
+// parseWhen parses the value of a date field, which is either "none", a
+// date, or an age.
+func parseWhen(tm *term) error {
+	if tm.value == "none" {
+		if tm.op != opEq {
+			return unsupportedOp(*tm)
+		}
+		tm.none = true
+		return nil
+	}
+	if d, err := time.ParseInLocation("2006-01-02", tm.value, time.Local); err == nil {
+		tm.dateVal = d
+		return nil
+	}
+	d, err := parseAge(tm.value)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s, expected YYYY-MM-DD, none or an age like 7d", tm.value, tm.field)
+	}
+	tm.durVal = d
+	tm.isDur = true
+	return nil
+}
+
+// parseAge parses an age such as 12h, 7d or 2w.
+func parseAge(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, errors.New("age too short")
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, err
+	}
+	switch s[len(s)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("unknown age unit %q", s[len(s)-1])
+}
+
+func unsupportedOp(tm term) error {
+	return fmt.Errorf("query field %q doesn't support operator %q", tm.field, tm.op)
+}
+
+// unquote removes surrounding double quotes from a value.
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Match reports whether the target matches every term of the query.
+// An empty query matches every target.
+func (q *Query) Match(tg Target) bool {
+	if tg.Task == nil {
+		return false
+	}
+	for _, tm := range q.terms {
+		if q.matchTerm(tm, tg) == tm.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *Query) matchTerm(tm term, tg Target) bool {
+	t := tg.Task
+	switch tm.field {
+	case "text":
+		return strings.Contains(strings.ToLower(t.Name+"\n"+t.Description), tm.value)
+	case "name":
+		return matchString(tm, t.Name)
+	case "desc":
+		return matchString(tm, t.Description)
+	case "column":
+		return tg.Board != "" && matchString(tm, tg.Column)
+	case "lane":
+		return tg.Board != "" && matchString(tm, tg.Lane)
+	case "board":
+		if tg.Board == "" {
+			return false
+		}
+		if tm.pattern != nil {
+			return tm.pattern.MatchString(strings.ToLower(tg.Board))
+		}
+		return strings.Contains(strings.ToLower(tg.Board), tm.value)
+	case "done":
+		return t.Done == tm.boolVal
+	case "blocked":
+		return t.Blocked == tm.boolVal
+	case "core":
+		return tg.IsCore == tm.boolVal
+	case "child":
+		return tg.HasChild == tm.boolVal
+	case "priority":
+		return compare(tm.op, t.Priority-tm.intVal)
+	case "started":
+		return q.matchWhen(tm, t.Started, false)
+	case "finished":
+		return q.matchWhen(tm, t.Finished, false)
+	case "due":
+		return q.matchWhen(tm, t.Due, true)
+	}
+	return false
+}
+
+// matchString matches a string field, ignoring case.
+func matchString(tm term, s string) bool {
+	s = strings.ToLower(s)
+	if tm.op == opContains {
+		return strings.Contains(s, tm.value)
+	}
+	return s == tm.value
+}
+
+// matchWhen matches a date field. Ages are measured from the date to now,
+// or from now to the date for dates in the future such as due dates.
+func (q *Query) matchWhen(tm term, d time.Time, future bool) bool {
+	if tm.none {
+		return d.IsZero()
+	}
+	if d.IsZero() {
+		return false
+	}
+	if tm.isDur {
+		age := q.Now().Sub(d)
+		if future {
+			age = d.Sub(q.Now())
+		}
+		if tm.op == opEq {
+			return age <= tm.durVal
+		}
+		return compare(tm.op, int(age-tm.durVal))
+	}
+	y1, m1, d1 := d.Date()
+	y2, m2, d2 := tm.dateVal.Date()
+	day := time.Date(y1, m1, d1, 0, 0, 0, 0, time.Local)
+	want := time.Date(y2, m2, d2, 0, 0, 0, 0, time.Local)
+	return compare(tm.op, int(day.Sub(want)))
+}
+
+// compare applies a comparison operator to the sign of a difference.
+func compare(op string, diff int) bool {
+	switch op {
+	case opEq:
+		return diff == 0
+	case opLt:
+		return diff < 0
+	case opGt:
+		return diff > 0
+	case opLe:
+		return diff <= 0
+	case opGe:
+		return diff >= 0
+	}
+	return false
+}
+
+// Target returns the todo list task as seen by a query.
+func (task TodoTask) Target() Target {
+	return Target{Task: task.Task, IsCore: task.IsCore}
+}
+
+// Target returns the board task in the given column of the given board
+// as seen by a query.
+func (tree *BoardTree) Target(b *Board, col int, task BoardTask) Target {
+	tg := Target{Task: task.Task, HasChild: task.HasChild, Board: tree.PathString(b.ID), Lane: task.Lane}
+	if col >= 0 && col < len(b.Columns) {
+		tg.Column = b.Columns[col].Title
+	}
+	return tg
+}
+
+// Filter returns every task of the todo list and the board tree that
+// matches the query, in the same order as [Search].
+func Filter(list *TodoList, tree *BoardTree, q *Query) []SearchResult {
+	var results []SearchResult
+	if list != nil {
+		for i, task := range list.Tasks {
+			if q.Match(task.Target()) {
+				results = append(results, SearchResult{Task: task.Task, Index: i})
+			}
+		}
+	}
+	if tree != nil {
+		for _, board := range tree.Boards() {
+			for c, col := range board.Columns {
+				for i, task := range col.Tasks {
+					if q.Match(tree.Target(board, c, task)) {
+						results = append(results, SearchResult{Task: task.Task, Board: board, Column: c, Index: i})
+					}
+				}
+			}
+		}
+	}
+	return results
+}
+
+// SavedQueries holds queries saved under a name.
+type SavedQueries struct {
+	Queries []SavedQuery `yaml:"queries"`
+}
+
+// A SavedQuery is a query saved under a name.
+type SavedQuery struct {
+	Name  string `yaml:"name"`
+	Query string `yaml:"query"`
+}
+
+// Get returns the query saved under the given name.
+func (sq *SavedQueries) Get(name string) (string, bool) {
+	for _, q := range sq.Queries {
+		if q.Name == name {
+			return q.Query, true
+		}
+	}
+	return "", false
+}
+
+// Save saves a query under the given name, replacing any query already
+// saved under that name. The query must parse.
+func (sq *SavedQueries) Save(name, query string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("query name must not be empty")
+	}
+	if _, err := ParseQuery(query); err != nil {
+		return err
+	}
+	for i := range sq.Queries {
+		if sq.Queries[i].Name == name {
+			sq.Queries[i].Query = query
+			return nil
+		}
+	}
+	sq.Queries = append(sq.Queries, SavedQuery{Name: name, Query: query})
+	return nil
+}
+
+// Remove removes the query saved under the given name.
+func (sq *SavedQueries) Remove(name string) error {
+	for i, q := range sq.Queries {
+		if q.Name == name {
+			sq.Queries = append(sq.Queries[:i], sq.Queries[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no query saved as %q", name)
+}
@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+)
+
+func ExampleParseQuery() {
+	now := time.Date(2023, 9, 10, 12, 0, 0, 0, time.Local)
+	q, err := ParseQuery(`done:false started:<7d board:Project/* name~"api"`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	q.Now = func() time.Time { return now }
+
+	targets := []Target{
+		{Task: &Task{Name: "Write API client", Started: now.AddDate(0, 0, -2)}, Board: "Project/Build"},
+		{Task: &Task{Name: "Write API docs", Started: now.AddDate(0, 0, -9)}, Board: "Project/Build"},
+		{Task: &Task{Name: "Fix API bug", Started: now, Done: true}, Board: "Project/Build"},
+		{Task: &Task{Name: "API review", Started: now}, Board: "Project"},
+		{Task: &Task{Name: "Read API book", Started: now}},
+	}
+	for _, tg := range targets {
+		fmt.Printf("%-16s %v\n", tg.Task.Name, q.Match(tg))
+	}
+
+	// Output:
+	// Write API client true
+	// Write API docs   false
+	// Fix API bug      false
+	// API review       false
+	// Read API book    false
+}
+
+func ExampleParseQuery_errors() {
+	for _, s := range []string{
+		`color:red`,
+		`done:maybe`,
+		`priority~1`,
+		`due:<soon`,
+		`name:"api`,
+	} {
+		_, err := ParseQuery(s)
+		fmt.Println(err)
+	}
+
+	// Output:
+	// unknown query field "color"
+	// invalid value "maybe" for done, expected true or false
+	// query field "priority" doesn't support operator "~"
+	// invalid value "soon" for due, expected YYYY-MM-DD, none or an age like 7d
+	// unterminated quote in query
+}
+
+func ExampleQuery_Match() {
+	now := time.Date(2023, 9, 10, 12, 0, 0, 0, time.Local)
+	q, _ := ParseQuery(`due:<3d -blocked:true parser`)
+	q.Now = func() time.Time { return now }
+
+	fmt.Println(q.Match(Target{Task: &Task{Name: "write parser", Due: now.AddDate(0, 0, 1)}}))
+	fmt.Println(q.Match(Target{Task: &Task{Name: "write parser", Due: now.AddDate(0, 0, 5)}}))
+	fmt.Println(q.Match(Target{Task: &Task{Name: "write parser", Due: now, Blocked: true}}))
+	fmt.Println(q.Match(Target{Task: &Task{Name: "write lexer", Due: now}}))
+
+	// Output:
+	// true
+	// false
+	// false
+	// false
+}
+
+func ExampleFilter() {
+	list := &TodoList{Tasks: []TodoTask{
+		{Task: &Task{Id: 1, Name: "Water plants"}, IsCore: true},
+		{Task: &Task{Id: 2, Name: "Buy groceries"}},
+	}}
+	tree := new(BoardTree)
+	root := tree.NewBoard("Project")
+	tree.AddRoot(root)
+	root.Columns[2].Add(&BoardTask{Task: &Task{Id: 3, Name: "Ship"}})
+	root.Columns[0].Add(&BoardTask{Task: &Task{Id: 4, Name: "Fix crash"}, Lane: "Bugs"})
+
+	q, _ := ParseQuery("core:true")
+	for _, r := range Filter(list, tree, q) {
+		fmt.Println(r.Task.Name)
+	}
+	q, _ = ParseQuery("column:done")
+	for _, r := range Filter(list, tree, q) {
+		fmt.Println(r.Task.Name)
+	}
+	q, _ = ParseQuery("lane~bug")
+	for _, r := range Filter(list, tree, q) {
+		fmt.Println(r.Task.Name)
+	}
+
+	// Output:
+	// Water plants
+	// Ship
+	// Fix crash
+}
+
+func ExampleSavedQueries() {
+	var sq SavedQueries
+	sq.Save("stale", "done:false started:>30d")
+	sq.Save("stale", "done:false started:>60d")
+	fmt.Println(sq.Save("broken", "done:maybe"))
+
+	q, ok := sq.Get("stale")
+	fmt.Println(q, ok)
+	fmt.Println(len(sq.Queries))
+
+	// Output:
+	// invalid value "maybe" for done, expected true or false
+	// done:false started:>60d true
+	// 1
+}
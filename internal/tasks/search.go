@@ -0,0 +1,72 @@
+package tasks
+
+import "strings"
+
+// A SearchResult locates a task that matches a search query.
+type SearchResult struct {
+	Task   *Task  // matching task
+	Board  *Board // board holding the task, nil for todo list tasks
+	Column int    // index of the board column holding the task
+	Index  int    // index of the task in its list or column
+}
+
+// Search returns every task of the todo list and the board tree whose
+// name or description contains all words of the query, ignoring case.
+// Todo list tasks come first, followed by board tasks in the order
+// returned by [BoardTree.Boards].
+func Search(list *TodoList, tree *BoardTree, query string) []SearchResult {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var results []SearchResult
+	if list != nil {
+		for i := range list.Tasks {
+			if matchesAll(list.Tasks[i].Task, words) {
+				results = append(results, SearchResult{Task: list.Tasks[i].Task, Index: i})
+			}
+		}
+	}
+	if tree != nil {
+		for _, board := range tree.Boards() {
+			for c := range board.Columns {
+				for i := range board.Columns[c].Tasks {
+					task := board.Columns[c].Tasks[i].Task
+					if matchesAll(task, words) {
+						results = append(results, SearchResult{Task: task, Board: board, Column: c, Index: i})
+					}
+				}
+			}
+		}
+	}
+	return results
+}
+
+// matchesAll reports whether the name or description of the task
+// contains every one of the given lower case words.
+func matchesAll(t *Task, words []string) bool {
+	if t == nil {
+		return false
+	}
+	text := strings.ToLower(t.Name + "\n" + t.Description)
+	for _, w := range words {
+		if !strings.Contains(text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// Location returns where the result's task lives: the title of the
+// todo list, or the board path and column title for board tasks.
+func (r SearchResult) Location(list *TodoList, tree *BoardTree) string {
+	if r.Board == nil {
+		return list.GetTitle()
+	}
+	loc := tree.PathString(r.Board.ID)
+	if r.Column >= 0 && r.Column < len(r.Board.Columns) {
+		loc += "/" + r.Board.Columns[r.Column].GetTitle()
+	}
+	return loc
+}
@@ -0,0 +1,22 @@
+package tasks
+
+import "fmt"
+
+func ExampleSearch() {
+	list := &TodoList{Title: "Daily TODOs", Tasks: []TodoTask{
+		{Task: &Task{Id: 1, Name: "Write API docs"}},
+		{Task: &Task{Id: 2, Name: "Buy groceries"}},
+	}}
+	tree := new(BoardTree)
+	root := tree.NewBoard("Project")
+	tree.AddRoot(root)
+	root.Columns[1].Add(&BoardTask{Task: &Task{Id: 3, Name: "Build", Description: "Design the api client"}})
+
+	for _, r := range Search(list, tree, "API") {
+		fmt.Printf("%d %q in %s\n", r.Task.Id, r.Task.Name, r.Location(list, tree))
+	}
+
+	// Output:
+	// 1 "Write API docs" in Daily TODOs
+	// 3 "Build" in Project/Working On
+}
@@ -8,22 +8,21 @@
 // This package currently implements task prioritization at the task
 // level. That is, each task has a priority field, where a lower value
 // indicates more importance. Task-level priority was chosen for its
-// straightforward implementation.
+// straightforward implementation. Priorities are sparse: neighbouring
+// tasks are [PriorityStep] apart, so a task can be given a priority
+// between two others without renumbering every task in the list.
+//
+// The order of tasks can also be derived from a [Prioritizer]. Each
+// todo list and board column names the prioritizer it is ordered by,
+// and built-in strategies order tasks manually, by due date, by age, or
+// by a weighted score of due date, priority and blocked status. Custom
+// strategies can be added with [RegisterPrioritizer], allowing users
+// to define their own criteria for what makes a task "important."
 //
 // Future Possibilities:
 //
-//  1. Dynamic Priority Algorith: Implementing an algorithm that
-//     automatically assigns and adjusts task priority based on various
-//     factors such as deadlines, dependencies, etc.
-//  2. Priority Queue: A more complex data structure like a priority
+//  1. Priority Queue: A more complex data structure like a priority
 //     queue could be employed to make add/delete operations more efficient.
-//  3. Custom Sort Queue: Allow custom sorting functions, allowing users
-//     to define their own criteria for what makes a task "important."
-//
-// To swap out different prioritization methods, one could implement a
-// Prioritization interface. By doing so, different sorting and
-// prioritization algorithms can be swapped in and out without affecting
-// the existing code base.
 //
 // Extensibility was kept in mind to enable developers to embed the
 // Task struct into their own specific task type, adding additional
@@ -41,8 +40,11 @@ type Task struct {
 	Started     time.Time `yaml:"started"`     // date task was created
 	Finished    time.Time `yaml:"finished"`    // date task was finished
 	Priority    int       `yaml:"priority"`    // Determines task urgency. Lower numbers indicate higher priority.
+	Due         time.Time `yaml:"due"`         // date task is due
+	Blocked     bool      `yaml:"blocked"`     // indicate whether or not the task is blocked
 	// TODO: move to TodoTask struct (?).
-	Done bool `yaml:"done"` // used to signify when a task is done
+	Done      bool            `yaml:"done"`                // used to signify when a task is done
+	Checklist []ChecklistItem `yaml:"checklist,omitempty"` // steps of the task
 }
 
 // ID returns the unique identifier of the task.
@@ -87,6 +89,18 @@ func (t Task) GetPriority() int { return t.Priority }
 // SetPriority sets the priority of the task.
 func (t *Task) SetPriority(p int) { t.Priority = p }
 
+// GetDue returns the date when the task is due.
+func (t Task) GetDue() time.Time { return t.Due }
+
+// SetDue sets the date when the task is due.
+func (t *Task) SetDue(d time.Time) { t.Due = d }
+
+// GetBlocked returns whether or not the task is blocked.
+func (t Task) GetBlocked() bool { return t.Blocked }
+
+// SetBlocked sets whether or not the task is blocked.
+func (t *Task) SetBlocked(b bool) { t.Blocked = b }
+
 // GetIsDone returns whether or not the task is finished.
 func (t Task) GetIsDone() bool { return t.Done }
 
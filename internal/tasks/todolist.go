@@ -1,9 +1,6 @@
 package tasks
 
-import (
-	"fmt"
-	"sync"
-)
+import "fmt"
 
 type TodoTask struct {
 	*Task
@@ -14,7 +11,9 @@ type TodoList struct {
 	Title       string     `yaml:"title"`
 	Tasks       []TodoTask `yaml:"tasks"`
 	buffer      *TodoTask
-	TaskCounter int `yaml:"task_counter"`
+	TaskCounter int        `yaml:"task_counter"`
+	Prioritizer string     `yaml:"prioritizer,omitempty"` // name of the prioritizer ordering the tasks
+	Archived    []TodoTask `yaml:"archived,omitempty"`    // tasks removed by archiving
 }
 
 //var _ TaskList = &TodoList{}
@@ -51,41 +50,62 @@ func (t *TodoList) Buffer() *TodoTask { return t.buffer }
 // SetBuff sets the buffered task.
 func (t *TodoList) SetBuff(task *TodoTask) { t.buffer = task }
 
-// UpdatePriorities updates the priorities of tasks from the given
-// start index.
-func (t *TodoList) UpdatePriorities(start int) error {
-	if err := t.Bounds(start); err != nil {
-		return fmt.Errorf("failed to update task priorities: %v\n", err)
+// Reprioritize assigns the task at the given index a priority between
+// the priorities of its neighbours. The whole list is only renumbered
+// when there is no gap left between the neighbours. Unless the list is
+// ordered manually, the task is given a priority after all others.
+func (t *TodoList) Reprioritize(index int) error {
+	if err := t.Bounds(index); err != nil {
+		return fmt.Errorf("failed to reprioritize task: %v", err)
 	}
+	if !isManual(t.Prioritizer) {
+		// The order of the list doesn't follow the priorities, so the
+		// task is placed last in the manual order.
+		t.Tasks[index].SetPriority(nextRank(func(i int) int { return t.Tasks[i].Priority }, len(t.Tasks), index))
+		return nil
+	}
+	prev, next := noRank, noRank
+	if index > 0 {
+		prev = t.Tasks[index-1].Priority
+	}
+	if index < len(t.Tasks)-1 {
+		next = t.Tasks[index+1].Priority
+	}
+	p, ok := rankBetween(prev, next)
+	if !ok {
+		t.Rebalance()
+		return nil
+	}
+	t.Tasks[index].SetPriority(p)
+	return nil
+}
 
-	var wg sync.WaitGroup
-
-	for i := start; i < len(t.Tasks); i++ {
-		// Increment the WaitGroup counter
-		wg.Add(1)
-
-		go func(i int) {
-			// Decrement the WaitGroup counter when the goroutine completes
-			defer wg.Done()
-
-			// Update the task's priority
-			t.Tasks[i].SetPriority(i)
-		}(i)
+// Rebalance renumbers the priorities of all tasks, leaving a gap of
+// [PriorityStep] between neighbouring tasks.
+func (t *TodoList) Rebalance() {
+	for i := range t.Tasks {
+		t.Tasks[i].SetPriority(i * PriorityStep)
 	}
+}
 
-	// Wait for all goroutines to complete
-	wg.Wait()
-	return nil
+// IndexOf returns the index of the given task in the list, or -1 if
+// the list doesn't contain it.
+func (t *TodoList) IndexOf(task *Task) int {
+	for i := range t.Tasks {
+		if t.Tasks[i].Task == task {
+			return i
+		}
+	}
+	return -1
 }
 
 // Add adds a task to the todo list at the given index.
 //
 // Important Considerations:
 //
-//  1. Update Priority: The removal of a task can affect the
-//     priorities of the remaining tasks. Calling [UpdatePriorities]
-//     post-removal should be done to update task priorities
-//     accordingly.
+//  1. Update Priority: The added task needs a priority that places it
+//     between its neighbours. Calling [Reprioritize] post-add should be
+//     done to update the task priority accordingly.
 //
 // Note: The priority updating of the tasks in the list is assumed to
 // be handled outside this function, and should be addressed post-add
@@ -103,6 +123,28 @@ func (t *TodoList) Add(task *TodoTask, index int) error {
 	return nil
 }
 
+// Move moves the task at the given index to the target index and
+// updates the priorities of the tasks affected by the move. Tasks are
+// only moved while ordered manually, since any other prioritizer would
+// undo the move.
+func (t *TodoList) Move(from, to int) error {
+	if !isManual(t.Prioritizer) {
+		return fmt.Errorf("ordered by %s, not manually", t.Prioritizer)
+	}
+	if err := t.Bounds(from); err != nil {
+		return fmt.Errorf("failed to move task: %v", err)
+	}
+	if err := t.Bounds(to); err != nil {
+		return fmt.Errorf("failed to move task: %v", err)
+	}
+	task, err := t.Remove(from)
+	if err != nil {
+		return err
+	}
+	t.Add(task, to)
+	return t.Reprioritize(to)
+}
+
 // Remove removes a task from the todo list by its index and returns the
 // removed task for buffering.
 //
@@ -110,13 +152,13 @@ func (t *TodoList) Add(task *TodoTask, index int) error {
 //
 //  1. Buffering. This function returns the removed task, which should
 //     be buffered.
-//  2. Update Priority: The removal of a task can affect the
-//     priorities of the remaining tasks. Calling a sort function
-//     post-removal should be done to update task priorities accordingly.
 //
-// Note: The buffering of the removed task and priority updating is
-// assumed to be handled outside this function, and they should be
-// addressed post-removal operation.
+// The priorities of the remaining tasks stay in order, so they don't
+// need to be updated.
+//
+// Note: The buffering of the removed task is assumed to be handled
+// outside this function, and should be addressed post-removal
+// operation.
 func (t *TodoList) Remove(index int) (*TodoTask, error) {
 	// Ensure index is in the correct range.
 	if err := t.Bounds(index); err != nil {
@@ -142,6 +184,9 @@ func (t *TodoTask) Copy(list *TodoList) TodoTask {
 		Started:     t.Started,
 		Finished:    t.Finished,
 		Priority:    t.Priority,
+		Due:         t.Due,
+		Blocked:     t.Blocked,
+		Checklist:   append([]ChecklistItem(nil), t.Checklist...),
 	}
 
 	newTodoTask := TodoTask{
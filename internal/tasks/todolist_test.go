@@ -30,7 +30,7 @@ func ExampleBounds_outOfBounds() {
 	// index 3 out of range.
 }
 
-func ExampleUpdatePriorities() {
+func ExampleTodoList_Rebalance() {
 	t1 := &Task{Name: "code", Priority: 0}
 	t2 := &Task{Name: "read", Priority: 1}
 	t3 := &Task{Name: "eat", Priority: 2}
@@ -43,7 +43,7 @@ func ExampleUpdatePriorities() {
 	list := TodoList{Tasks: []TodoTask{task3, task1, task2}}
 
 	// Update the tasks priority
-	list.UpdatePriorities(0)
+	list.Rebalance()
 
 	for _, t := range list.Tasks {
 		fmt.Printf("Task: %6q  Priority: %d\n", t.Name, t.Priority)
@@ -51,8 +51,8 @@ func ExampleUpdatePriorities() {
 
 	// Output:
 	// Task:  "eat"  Priority: 0
-	// Task: "code"  Priority: 1
-	// Task: "read"  Priority: 2
+	// Task: "code"  Priority: 1024
+	// Task: "read"  Priority: 2048
 }
 
 func ExampleAdd() {
@@ -132,3 +132,42 @@ func ExampleRemove() {
 	// - "code"
 	// - "eat"
 }
+
+func ExampleTodoList_Move() {
+	list := TodoList{Tasks: []TodoTask{
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "read", Priority: 1024}},
+		{Task: &Task{Name: "eat", Priority: 2048}},
+	}}
+	list.Move(2, 0)
+	list.Move(1, 2)
+
+	for _, t := range list.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// Task: "eat"  Priority: -1024
+	// Task: "read"  Priority: 1024
+	// Task: "code"  Priority: 2048
+}
+
+func ExampleTodoList_SpreadPriorities() {
+	// Priorities saved before they were spread out, listed in the order
+	// of another prioritizer.
+	list := TodoList{Prioritizer: ByDue, Tasks: []TodoTask{
+		{Task: &Task{Name: "read", Priority: 1}},
+		{Task: &Task{Name: "code", Priority: 0}},
+		{Task: &Task{Name: "eat", Priority: 2}},
+	}}
+	list.SpreadPriorities()
+
+	for _, t := range list.Tasks {
+		fmt.Printf("Task: %q  Priority: %d\n", t.Name, t.Priority)
+	}
+
+	// Output:
+	// Task: "read"  Priority: 1024
+	// Task: "code"  Priority: 0
+	// Task: "eat"  Priority: 2048
+}
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// crumbSep separates the segments of the breadcrumb.
+const crumbSep = " › "
+
+// crumbRoot is the region of the first breadcrumb segment, which leads
+// to the tree view.
+const crumbRoot = "tree"
+
+// initBreadcrumb initializes the breadcrumb shown above a board. Each
+// segment is a region, selected with the breadcrumb command or a click.
+func (t *TUI) initBreadcrumb() {
+	t.breadcrumb = tview.NewTextView().
+		SetDynamicColors(true).
+		SetRegions(true).
+		SetWrap(false)
+	t.breadcrumb.SetHighlightedFunc(func(added, _, _ []string) {
+		// Moving the selection with keys highlights segments too, but only
+		// Enter jumps.
+		if len(added) == 0 || t.crumbSelecting {
+			return
+		}
+		t.jumpToCrumb(added[0])
+	})
+	t.breadcrumb.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch keyName(event) {
+		case "h", "Left":
+			t.selectCrumb(-1)
+		case "l", "Right":
+			t.selectCrumb(1)
+		case "Enter":
+			t.crumbSelecting = false
+			if ids := t.breadcrumb.GetHighlights(); len(ids) > 0 {
+				t.jumpToCrumb(ids[0])
+			}
+		case "Esc":
+			t.crumbSelecting = false
+			t.breadcrumb.Highlight()
+			t.app.SetFocus(t.crumbPrevFocus)
+		}
+		return nil
+	})
+}
+
+// updateBreadcrumb shows the path of boards leading to the given board.
+func (t *TUI) updateBreadcrumb(b *tasks.Board) {
+	path, err := t.treeData.Path(b.GetID())
+	if err != nil {
+		path = []*tasks.Board{b}
+	}
+	segments := []string{fmt.Sprintf(`["%s"]Boards[""]`, crumbRoot)}
+	for i, pb := range path {
+		segment := fmt.Sprintf(`["%d"]%s[""]`, pb.GetID(), tview.Escape(pb.GetTitle()))
+		if i == len(path)-1 {
+			segment = "[::b]" + segment + "[::-]"
+		}
+		segments = append(segments, segment)
+	}
+	t.breadcrumb.SetText(strings.Join(segments, crumbSep))
+	t.crumbPath = t.boardPath(b)
+}
+
+// boardPath returns the titles of the boards leading to a board,
+// separated like the breadcrumb.
+func (t *TUI) boardPath(b *tasks.Board) string {
+	path, err := t.treeData.Path(b.GetID())
+	if err != nil {
+		return b.GetTitle()
+	}
+	titles := make([]string, len(path))
+	for i, pb := range path {
+		titles[i] = pb.GetTitle()
+	}
+	return strings.Join(titles, crumbSep)
+}
+
+// focusBreadcrumb focuses the breadcrumb and selects the parent of the
+// displayed board.
+func (t *TUI) focusBreadcrumb() {
+	t.crumbPrevFocus = t.app.GetFocus()
+	t.crumbSelecting = true
+	regions := t.crumbRegions()
+	if len(regions) > 1 {
+		t.breadcrumb.Highlight(regions[len(regions)-2])
+	} else {
+		t.breadcrumb.Highlight(regions[0])
+	}
+	t.app.SetFocus(t.breadcrumb)
+}
+
+// selectCrumb moves the breadcrumb selection by the given number of
+// segments.
+func (t *TUI) selectCrumb(offset int) {
+	regions := t.crumbRegions()
+	idx := 0
+	if ids := t.breadcrumb.GetHighlights(); len(ids) > 0 {
+		for i, r := range regions {
+			if r == ids[0] {
+				idx = i
+			}
+		}
+	}
+	idx += offset
+	if idx < 0 || idx >= len(regions) {
+		return
+	}
+	t.breadcrumb.Highlight(regions[idx])
+}
+
+// crumbRegions returns the regions of the breadcrumb segments in order.
+func (t *TUI) crumbRegions() []string {
+	regions := []string{crumbRoot}
+	if b := t.nodeBoard(t.tree.GetCurrentNode()); b != nil {
+		if path, err := t.treeData.Path(b.GetID()); err == nil {
+			for _, pb := range path {
+				regions = append(regions, strconv.Itoa(pb.GetID()))
+			}
+		}
+	}
+	return regions
+}
+
+// jumpToCrumb shows the board of a breadcrumb segment, or the tree view
+// for the first segment.
+func (t *TUI) jumpToCrumb(region string) {
+	t.breadcrumb.Highlight()
+	if region == crumbRoot {
+		t.showTreeView()
+		return
+	}
+	id, err := strconv.Atoi(region)
+	if err != nil {
+		return
+	}
+	b, err := t.treeData.GetBoard(id)
+	if err == nil {
+		err = t.openBoard(b)
+	}
+	if err != nil {
+		t.errorf("Failed to open board: %v", err)
+	}
+}
+
+// runBreadcrumb selects a breadcrumb segment. With an argument, it
+// jumps that many levels up instead.
+func runBreadcrumb(t *TUI, _ context, args []string) error {
+	if len(args) == 0 {
+		t.focusBreadcrumb()
+		return nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return fmt.Errorf("invalid number of levels %q", args[0])
+	}
+	regions := t.crumbRegions()
+	idx := len(regions) - 1 - n
+	if idx < 0 {
+		return errors.New("not that many levels up")
+	}
+	t.jumpToCrumb(regions[idx])
+	return nil
+}
+
+// updateTerminalTitle sets the terminal title to the path of the
+// displayed board. The title of the terminal is saved before it is
+// first changed and restored by restoreTerminalTitle.
+func (t *TUI) updateTerminalTitle() {
+	title := "bp"
+	if t.crumbPath != "" {
+		title += ": " + t.crumbPath
+	}
+	if title == t.termTitle || !isTerminal(os.Stdout) {
+		return
+	}
+	if t.termTitle == "" {
+		fmt.Fprint(os.Stdout, "\x1b[22;0t") // save the title
+	}
+	t.termTitle = title
+	fmt.Fprintf(os.Stdout, "\x1b]2;%s\x07", stripControl(title))
+}
+
+// restoreTerminalTitle restores the terminal title saved before it was
+// first changed.
+func (t *TUI) restoreTerminalTitle() {
+	if t.termTitle != "" {
+		fmt.Fprint(os.Stdout, "\x1b[23;0t")
+		t.termTitle = ""
+	}
+}
+
+// isTerminal reports whether the file is a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stripControl removes control characters, which would end or corrupt
+// an escape sequence.
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+func ExampleTUI_boardPath() {
+	tree := new(tasks.BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	build := tree.NewBoard("Build/CI")
+	tree.AddChildBoard(build)
+	project.AddChild(build.GetID())
+
+	t := &TUI{treeData: tree}
+	fmt.Println(t.boardPath(build))
+
+	// Output:
+	// Project › Build/CI
+}
+
+func Example_stripControl() {
+	fmt.Printf("%q\n", stripControl("bp: Pro\x07ject\x1b]"))
+
+	// Output:
+	// "bp: Project]"
+}
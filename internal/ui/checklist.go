@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/rivo/tview"
+)
+
+// Checklist items in the checklist field of the task forms are
+// prefixed by whether they are done, so that any name round-trips.
+const (
+	doneItemPrefix    = "[x] "
+	pendingItemPrefix = "[ ] "
+)
+
+// checklistBadge returns the number of done checklist items of a task
+// out of all, such as " (1/3)", or an empty string if the task has no
+// checklist.
+func checklistBadge(task *tasks.Task) string {
+	done, total := task.ChecklistCount()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d/%d)", done, total)
+}
+
+// taskDetails returns the lines shown below a task with its description
+// shown: the description rendered from Markdown and wrapped to the given
+// width, with code in the given color, followed by the checklist items.
+func taskDetails(task *tasks.Task, width int, code string) []string {
+	lines := renderMarkdown(task.GetDesc(), width, code)
+	for _, item := range task.GetChecklist() {
+		mark := "[ []"
+		if item.Done {
+			mark = "[x[]"
+		}
+		lines = append(lines, "  "+mark+tview.Escape(item.Name))
+	}
+	return lines
+}
+
+// formatChecklist returns the checklist items one per line, prefixed
+// by [doneItemPrefix] or [pendingItemPrefix].
+func formatChecklist(items []tasks.ChecklistItem) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = pendingItemPrefix + item.Name
+		if item.Done {
+			lines[i] = doneItemPrefix + item.Name
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseChecklist returns the checklist items of the given text, one per
+// non-empty line. It is the inverse of [formatChecklist]. Lines without
+// a prefix are added as items not done.
+func parseChecklist(text string) []tasks.ChecklistItem {
+	var items []tasks.ChecklistItem
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		item := tasks.ChecklistItem{Name: line}
+		switch {
+		case strings.HasPrefix(strings.ToLower(line), doneItemPrefix):
+			item = tasks.ChecklistItem{Name: strings.TrimSpace(line[len(doneItemPrefix):]), Done: true}
+		case strings.HasPrefix(line, pendingItemPrefix):
+			item.Name = strings.TrimSpace(line[len(pendingItemPrefix):])
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// selectedTask returns the selected todo list task or board task.
+func (t *TUI) selectedTask(ctx context) (*tasks.Task, error) {
+	if ctx == ctxList {
+		task, err := t.taskData.GetTask(t.listIdx())
+		if err != nil {
+			return nil, err
+		}
+		return task.Task, nil
+	}
+	task, err := t.boardColsData[t.focusedCol].GetTask(t.boardTaskIdx())
+	if err != nil {
+		return nil, err
+	}
+	return task.Task, nil
+}
+
+// refreshTask redraws the selected todo list task or board task, keeping
+// it selected.
+func (t *TUI) refreshTask(ctx context, task *tasks.Task) {
+	if ctx == ctxList {
+		t.filterAndUpdateList(t.leftPanelWidth)
+		for i, lt := range t.taskData.GetTasks() {
+			if lt.Task == task {
+				t.list.Select(t.calcRow(i, t.leftPanelWidth), 0)
+			}
+		}
+		return
+	}
+	t.updateColumn(t.focusedCol)
+	for i, bt := range t.boardColsData[t.focusedCol].GetTasks() {
+		if bt.Task == task {
+			t.boardCols[t.focusedCol].Select(t.calcRowBoard(i, t.colWidth()), 0)
+		}
+	}
+}
+
+// runCheck toggles the checklist item with the given number of the
+// selected task.
+func runCheck(t *TUI, ctx context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: check <number>")
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid checklist item number %q", args[0])
+	}
+	task, err := t.selectedTask(ctx)
+	if err != nil {
+		return err
+	}
+	if err := task.ToggleChecklistItem(n - 1); err != nil {
+		return err
+	}
+	t.refreshTask(ctx, task)
+	return nil
+}
+
+// runItem adds a checklist item to the selected task.
+func runItem(t *TUI, ctx context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: item <name>")
+	}
+	task, err := t.selectedTask(ctx)
+	if err != nil {
+		return err
+	}
+	task.AddChecklistItem(strings.Join(args, " "))
+	t.refreshTask(ctx, task)
+	return nil
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+func Example_taskDetails() {
+	task := &tasks.Task{Description: "Ship the release"}
+	task.SetChecklist(parseChecklist("[x] write notes\n\n tag version "))
+	fmt.Printf("%q\n", checklistBadge(task))
+	for _, line := range taskDetails(task, 20, "") {
+		fmt.Println(line)
+	}
+	fmt.Printf("%q\n", formatChecklist(task.GetChecklist()))
+
+	// Output:
+	// " (1/2)"
+	// Ship the release
+	//   [x[]write notes
+	//   [ []tag version
+	// "[x] write notes\n[ ] tag version"
+}
+
+func Example_formatChecklist_roundTrip() {
+	items := []tasks.ChecklistItem{{Name: "x ray"}, {Name: "[x] box", Done: true}}
+	text := formatChecklist(items)
+	fmt.Printf("%q\n", text)
+	fmt.Println(parseChecklist(text))
+
+	// Output:
+	// "[ ] x ray\n[x] [x] box"
+	// [{x ray false} {[x] box true}]
+}
@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// cmdLabel is the command line label.
+const cmdLabel = ":"
+
+// commandLine is an ex-style command line. It keeps the command history
+// and the state of tab completion.
+type commandLine struct {
+	*tview.InputField
+	ctx        context         // context the command line was opened from
+	prevFocus  tview.Primitive // primitive focused before opening
+	history    []string        // entered commands, oldest first
+	histIdx    int             // index into history while browsing it
+	matches    []string        // completions cycled through with tab
+	matchIdx   int
+	completing bool // the text is being set to a completion
+}
+
+// SetSaveFunc sets the function that the write command uses to save
+// the todo list and boards.
+func (t *TUI) SetSaveFunc(save func() error) { t.save = save }
+
+// initCommandLine initializes the command line.
+func (t *TUI) initCommandLine() {
+	cl := &commandLine{
+		InputField: tview.NewInputField().
+			SetLabel(cmdLabel).
+			SetFieldWidth(0),
+	}
+	cl.SetChangedFunc(func(string) {
+		// Typing ends a completion cycle.
+		if !cl.completing {
+			cl.matches = nil
+		}
+	})
+	cl.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTab, tcell.KeyBacktab:
+			dir := 1
+			if event.Key() == tcell.KeyBacktab {
+				dir = -1
+			}
+			t.completeCommandLine(dir)
+			return nil
+		case tcell.KeyUp:
+			t.browseHistory(-1)
+			return nil
+		case tcell.KeyDown:
+			t.browseHistory(1)
+			return nil
+		}
+		return event
+	})
+	cl.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			input := strings.TrimSpace(cl.GetText())
+			if input == "" {
+				break
+			}
+			cl.history = append(cl.history, input)
+			t.hideCommandLine()
+			if err := t.execCommandLine(cl.ctx, input); err != nil {
+				t.errorf("%v", err)
+			}
+			return
+		case tcell.KeyEscape:
+		default:
+			return
+		}
+		t.hideCommandLine()
+	})
+	t.cmdLine = cl
+}
+
+// showCommandLine shows the command line and focuses it. Commands run
+// in the context that had focus before.
+func (t *TUI) showCommandLine() {
+	t.cmdLine.ctx = t.focusContext()
+	t.cmdLine.prevFocus = t.app.GetFocus()
+	t.cmdLine.histIdx = len(t.cmdLine.history)
+	t.cmdLine.SetText("")
+	t.layout.ResizeItem(t.cmdLine, 1, 0)
+	t.app.SetFocus(t.cmdLine)
+}
+
+// hideCommandLine hides the command line and returns focus to the
+// primitive focused before it was opened.
+func (t *TUI) hideCommandLine() {
+	t.layout.ResizeItem(t.cmdLine, 0, 0)
+	t.app.SetFocus(t.cmdLine.prevFocus)
+}
+
+// execCommandLine parses and runs a command line input in the given
+// context.
+func (t *TUI) execCommandLine(ctx context, input string) error {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return nil
+	}
+	return t.runCommand(ctx, fields[0], fields[1:])
+}
+
+// browseHistory replaces the command line text with an older, or if
+// dir is positive, newer history entry.
+func (t *TUI) browseHistory(dir int) {
+	cl := t.cmdLine
+	idx := cl.histIdx + dir
+	if idx < 0 || idx > len(cl.history) {
+		return
+	}
+	cl.histIdx = idx
+	if idx == len(cl.history) {
+		cl.SetText("")
+		return
+	}
+	cl.SetText(cl.history[idx])
+}
+
+// completeCommandLine replaces the command line text with the next
+// completion, or the previous one if dir is negative.
+func (t *TUI) completeCommandLine(dir int) {
+	cl := t.cmdLine
+	if cl.matches == nil {
+		cl.matches = t.completions(cl.ctx, cl.GetText())
+		if len(cl.matches) == 0 {
+			cl.matches = nil
+			return
+		}
+		cl.matchIdx = -1
+		if dir < 0 {
+			cl.matchIdx = len(cl.matches)
+		}
+	}
+	n := len(cl.matches)
+	cl.matchIdx = ((cl.matchIdx+dir)%n + n) % n
+	cl.completing = true
+	cl.SetText(cl.matches[cl.matchIdx])
+	cl.completing = false
+}
+
+// completions returns the completed command line texts for the given
+// text. The command name is completed from the commands available in
+// the context and arguments are completed by the command itself.
+func (t *TUI) completions(ctx context, text string) []string {
+	name, arg, hasArg := strings.Cut(strings.TrimLeft(text, " "), " ")
+	if !hasArg {
+		var names []string
+		for _, c := range commands {
+			if c.availableIn(ctx) && strings.HasPrefix(c.name, name) {
+				names = append(names, c.name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	c, ok := lookupCommand(name)
+	if !ok || c.complete == nil {
+		return nil
+	}
+	var texts []string
+	for _, word := range c.complete(t, arg) {
+		texts = append(texts, name+" "+word)
+	}
+	return texts
+}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rivo/tview"
+)
+
+// defaultMinColWidth is the minimum width of a board column, with its
+// border, unless configured otherwise.
+const defaultMinColWidth = 24
+
+// minColWidthLimit is the smallest configurable minimum column width.
+const minColWidthLimit = 8
+
+// collapsedColWidth is the width of a collapsed board column, with its
+// border. It fits the number of tasks in the column.
+const collapsedColWidth = 5
+
+// colMinWidths returns the minimum width of each column of the
+// displayed board.
+func (t *TUI) colMinWidths() []int {
+	minWidth := t.minColWidth
+	if minWidth == 0 {
+		minWidth = defaultMinColWidth
+	}
+	widths := make([]int, len(t.boardColsData))
+	for i, col := range t.boardColsData {
+		widths[i] = minWidth
+		if col.GetCollapsed() {
+			widths[i] = collapsedColWidth
+		}
+	}
+	return widths
+}
+
+// visibleCols returns the first and the number of the columns of the
+// given widths that fit into width. The columns shown start at first,
+// unless that hides the focused column, and start further left if there
+// is room. At least the focused column is shown.
+func visibleCols(widths []int, width, first, focused int) (int, int) {
+	if len(widths) == 0 {
+		return 0, 0
+	}
+	if first > focused {
+		first = focused
+	}
+	sum := func(from, to int) int {
+		n := 0
+		for _, w := range widths[from:to] {
+			n += w
+		}
+		return n
+	}
+	for first < focused && sum(first, focused+1) > width {
+		first++
+	}
+	last := focused + 1
+	for last < len(widths) && sum(first, last+1) <= width {
+		last++
+	}
+	for first > 0 && sum(first-1, last) <= width {
+		first--
+	}
+	if last > len(widths) {
+		last = len(widths)
+	}
+	return first, last - first
+}
+
+// shownCols returns the first and the number of the columns of the
+// displayed board shown in the right panel.
+func (t *TUI) shownCols() (int, int) {
+	return visibleCols(t.colMinWidths(), t.boardWidth(), t.firstCol, t.focusedCol)
+}
+
+// boardWidth returns the width available to the columns of the
+// displayed board.
+func (t *TUI) boardWidth() int {
+	return t.rightPanelWidth - 2*borderWidth
+}
+
+// colWidth returns the width of the text in the expanded columns of the
+// displayed board.
+func (t *TUI) colWidth() int {
+	first, n := t.shownCols()
+	width, expanded := t.boardWidth(), 0
+	for _, col := range t.boardColsData[first : first+n] {
+		if col.GetCollapsed() {
+			width -= collapsedColWidth
+			continue
+		}
+		expanded++
+	}
+	if expanded == 0 {
+		return 1
+	}
+	return width/expanded - borderWidth
+}
+
+// layoutBoard shows the columns of the displayed board that fit into
+// the right panel, scrolled so that the focused column is shown. The
+// title of the panel tells which columns are shown if not all are.
+func (t *TUI) layoutBoard() {
+	if !t.boardShown() || len(t.boardCols) == 0 {
+		return
+	}
+	first, n := t.shownCols()
+	t.firstCol = first
+	widths := make([]int, n)
+	t.board.Clear()
+	for i := 0; i < n; i++ {
+		if t.boardColsData[first+i].GetCollapsed() {
+			widths[i] = collapsedColWidth
+		}
+		t.board.AddItem(t.boardCols[first+i], 0, i, 1, 1, 0, 0, first+i == t.focusedCol)
+	}
+	t.board.SetColumns(widths...)
+
+	b := t.nodeBoard(t.tree.GetCurrentNode())
+	if b == nil {
+		return
+	}
+	title := b.GetTitle()
+	if n < len(t.boardCols) {
+		title += fmt.Sprintf(" %s%d–%d of %d%s", pageMark(first > 0, "‹ "),
+			first+1, first+n, len(t.boardCols), pageMark(first+n < len(t.boardCols), " ›"))
+	}
+	t.rightPanel.SetTitle(title)
+}
+
+// pageMark returns the mark if there are more columns in its direction.
+func pageMark(more bool, mark string) string {
+	if more {
+		return mark
+	}
+	return ""
+}
+
+// columnShown reports whether the column of the displayed board at the
+// given index is shown.
+func (t *TUI) columnShown(idx int) bool {
+	first, n := t.shownCols()
+	return idx >= first && idx < first+n
+}
+
+// pageColumns focuses the first column of the next page of columns, or
+// the last column of the previous page if dir is negative.
+func (t *TUI) pageColumns(dir int) {
+	if t.isEmptyTable {
+		return
+	}
+	first, n := t.shownCols()
+	if dir > 0 {
+		if first+n >= len(t.boardCols) {
+			return
+		}
+		t.firstCol = first + n
+		t.focusColumn(first + n)
+		return
+	}
+	if first == 0 {
+		return
+	}
+	t.firstCol = first - n
+	if t.firstCol < 0 {
+		t.firstCol = 0
+	}
+	t.focusColumn(first - 1)
+}
+
+// toggleCollapse collapses the focused column of the displayed board,
+// or expands it if collapsed. A collapsed column shows the number of
+// its tasks only.
+func (t *TUI) toggleCollapse() {
+	col := &t.boardColsData[t.focusedCol]
+	col.SetCollapsed(!col.GetCollapsed())
+	if col.GetCollapsed() {
+		// There are no tasks to select in a collapsed column.
+		t.boardCols[t.focusedCol].SetSelectable(false, false)
+	}
+	for i := range t.boardCols {
+		t.updateColumn(i)
+	}
+}
+
+// updateCollapsedColumn shows the number of tasks in a collapsed column.
+func (t *TUI) updateCollapsedColumn(table *tview.Table, colIdx int) {
+	n := 0
+	for _, task := range t.boardColsData[colIdx].GetTasks() {
+		if t.boardTaskVisible(colIdx, task) {
+			n++
+		}
+	}
+	table.SetCell(0, 0, tview.NewTableCell(strconv.Itoa(n)).
+		SetAlign(tview.AlignCenter).
+		SetExpansion(1).
+		SetSelectable(false))
+}
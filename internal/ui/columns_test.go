@@ -0,0 +1,17 @@
+package ui
+
+import "fmt"
+
+func Example_visibleCols() {
+	widths := []int{24, 24, 5, 24, 24}
+	fmt.Println(visibleCols(widths, 60, 0, 0))
+	fmt.Println(visibleCols(widths, 60, 0, 4))
+	fmt.Println(visibleCols(widths, 60, 3, 1))
+	fmt.Println(visibleCols(widths, 20, 0, 3))
+
+	// Output:
+	// 0 3
+	// 2 3
+	// 1 3
+	// 3 1
+}
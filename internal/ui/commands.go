@@ -0,0 +1,931 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// A context names the part of the UI that has focus. Key bindings are
+// defined per context and commands are only available in some of them.
+type context string
+
+const (
+	ctxGlobal context = "global" // anywhere outside of input fields
+	ctxList   context = "list"   // the todo list
+	ctxTree   context = "tree"   // the board tree view
+	ctxBoard  context = "board"  // a displayed board, including one without columns
+	ctxColumn context = "column" // a board column as a whole
+	ctxTask   context = "task"   // a task of a board column
+)
+
+// A command is a named action. Commands are run from the command line
+// or through the key bindings.
+type command struct {
+	name     string
+	aliases  []string
+	usage    string    // arguments the command accepts
+	desc     string    // one line description
+	contexts []context // contexts the command is available in, nil for all
+	run      func(t *TUI, ctx context, args []string) error
+	// complete returns the candidates for the command argument being
+	// typed, if the command can complete its arguments.
+	complete func(t *TUI, arg string) []string
+}
+
+// availableIn reports whether the command can be run in the given
+// context.
+func (c *command) availableIn(ctx context) bool {
+	if c.contexts == nil {
+		return true
+	}
+	for _, cc := range c.contexts {
+		if cc == ctx {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	boardContexts = []context{ctxBoard, ctxColumn, ctxTask}
+	itemContexts  = []context{ctxList, ctxTree, ctxColumn, ctxTask}
+)
+
+// commands is the registry of all commands. It is filled in init, since
+// the command line completes command names from the registry.
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{name: "quit", aliases: []string{"q"}, desc: "Quit", run: func(t *TUI, _ context, _ []string) error {
+			t.app.Stop()
+			return nil
+		}},
+		{name: "write", aliases: []string{"w"}, desc: "Save the todo list and boards", run: func(t *TUI, _ context, _ []string) error {
+			if t.save == nil {
+				return errors.New("no save function set")
+			}
+			if err := t.save(); err != nil {
+				return err
+			}
+			t.markSaved()
+			t.infof("Saved")
+			return nil
+		}},
+		{name: "help", desc: "Show the key bindings and commands", run: func(t *TUI, _ context, _ []string) error {
+			t.showHelp()
+			return nil
+		}},
+		{name: "command-line", desc: "Open the command line", run: func(t *TUI, _ context, _ []string) error {
+			t.showCommandLine()
+			return nil
+		}},
+		{name: "zoom", desc: "Toggle panel zoom", run: func(t *TUI, _ context, _ []string) error {
+			t.toggleZoom()
+			return nil
+		}},
+		{name: "switch-panel", desc: "Switch panel focus", run: func(t *TUI, _ context, _ []string) error {
+			t.switchPanel()
+			return nil
+		}},
+		{name: "search", desc: "Search tasks", run: func(t *TUI, _ context, _ []string) error {
+			t.showSearch()
+			return nil
+		}},
+		{name: "next-match", desc: "Jump to the next search match", run: func(t *TUI, _ context, _ []string) error {
+			t.nextMatch(1)
+			return nil
+		}},
+		{name: "prev-match", desc: "Jump to the previous search match", run: func(t *TUI, _ context, _ []string) error {
+			t.nextMatch(-1)
+			return nil
+		}},
+		{name: "filter", usage: "[query]", desc: "Filter tasks by a query", run: runFilter, complete: completeFilter},
+		{name: "find-board", desc: "Go to a board", run: func(t *TUI, _ context, _ []string) error {
+			t.showBoardFinder()
+			return nil
+		}},
+		{name: "down", desc: "Move down", contexts: itemContexts, run: runDown},
+		{name: "up", desc: "Move up", contexts: itemContexts, run: runUp},
+		{name: "add", desc: "Add a task, board or column", contexts: append(itemContexts, ctxBoard), run: runAdd},
+		{name: "edit", desc: "Edit the selected item", contexts: itemContexts, run: runEdit},
+		{name: "yank", desc: "Copy the selected item", contexts: itemContexts, run: runYank},
+		{name: "delete", desc: "Delete and copy the selected item", contexts: itemContexts, run: runDelete},
+		{name: "paste", desc: "Paste below the selected item", contexts: itemContexts, run: runPaste},
+		{name: "scroll-left", desc: "Scroll the tree view left", contexts: []context{ctxTree}, run: func(t *TUI, _ context, _ []string) error {
+			t.tree.scroll(-treeScrollStep)
+			return nil
+		}},
+		{name: "scroll-right", desc: "Scroll the tree view right", contexts: []context{ctxTree}, run: func(t *TUI, _ context, _ []string) error {
+			t.tree.scroll(treeScrollStep)
+			return nil
+		}},
+		{name: "focus-subtree", desc: "Show only the subtree of the current node, or the whole tree again", contexts: []context{ctxTree}, run: runFocusSubtree},
+		{name: "rename", usage: "<name>", desc: "Rename the selected item", contexts: append(itemContexts, ctxBoard), run: runRename},
+		{name: "toggle-done", desc: "Toggle task completion", contexts: []context{ctxList}, run: func(t *TUI, _ context, _ []string) error {
+			return t.toggleTaskDone(t.listIdx())
+		}},
+		{name: "toggle-desc", desc: "Toggle task description and checklist", contexts: []context{ctxList, ctxTask}, run: runToggleDesc},
+		{name: "details", desc: "Show the task with its description and checklist", contexts: []context{ctxList, ctxTask}, run: runDetails},
+		{name: "edit-desc", desc: "Edit task description in $EDITOR", contexts: []context{ctxList, ctxTask}, run: runEditDesc},
+		{name: "check", usage: "<number>", desc: "Toggle a checklist item of the task", contexts: []context{ctxList, ctxTask}, run: runCheck},
+		{name: "item", usage: "<name>", desc: "Add a checklist item to the task", contexts: []context{ctxList, ctxTask}, run: runItem},
+		{name: "move-down", desc: "Move task down", contexts: []context{ctxList, ctxTask}, run: reorder(1)},
+		{name: "move-up", desc: "Move task up", contexts: []context{ctxList, ctxTask}, run: reorder(-1)},
+		{name: "move-top", desc: "Move task to the top", contexts: []context{ctxList, ctxTask}, run: reorderEnd(-1)},
+		{name: "move-bottom", desc: "Move task to the bottom", contexts: []context{ctxList, ctxTask}, run: reorderEnd(1)},
+		{name: "sort", usage: "[prioritizer]", desc: "Order tasks by a prioritizer", contexts: []context{ctxList, ctxColumn, ctxTask}, run: runSort, complete: completeSort},
+		{name: "archive", desc: "Archive done list tasks, a column's tasks or a task", contexts: []context{ctxList, ctxColumn, ctxTask}, run: runArchive},
+		{name: "newboard", usage: "<title>", desc: "Create a root board, or a sub-board for the task", run: runNewBoard},
+		{name: "enter", desc: "Enter the board or sub-board", contexts: []context{ctxTree, ctxTask}, run: runEnter},
+		{name: "back", desc: "Go back to the previous board", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			t.navBack()
+			return nil
+		}},
+		{name: "breadcrumb", usage: "[levels]", desc: "Select a board of the breadcrumb, or go up a number of levels", contexts: boardContexts, run: runBreadcrumb},
+		{name: "left", desc: "Focus the column to the left", contexts: boardContexts, run: runLeft},
+		{name: "right", desc: "Focus the column to the right", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			if !t.isEmptyTable && t.focusedCol < len(t.boardCols)-1 {
+				t.focusColumn(t.focusedCol + 1)
+			}
+			return nil
+		}},
+		{name: "page-left", desc: "Show the previous columns that don't fit", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			t.pageColumns(-1)
+			return nil
+		}},
+		{name: "page-right", desc: "Show the next columns that don't fit", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			t.pageColumns(1)
+			return nil
+		}},
+		{name: "collapse", desc: "Collapse or expand the column", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			if t.isEmptyTable {
+				return errors.New("board has no columns")
+			}
+			t.toggleCollapse()
+			return nil
+		}},
+		{name: "first-column", desc: "Focus the first column", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			if !t.isEmptyTable {
+				t.focusColumn(0)
+			}
+			return nil
+		}},
+		{name: "last-column", desc: "Focus the last column", contexts: boardContexts, run: func(t *TUI, _ context, _ []string) error {
+			if !t.isEmptyTable {
+				t.focusColumn(len(t.boardCols) - 1)
+			}
+			return nil
+		}},
+		{name: "cycle", desc: "Move task to the next column", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			t.cycleBoardTask(t.taskRow())
+			return nil
+		}},
+		{name: "shift-left", desc: "Move task to the previous column", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			t.shiftBoardTask(t.taskRow(), -1)
+			return nil
+		}},
+		{name: "shift-right", desc: "Move task to the next column", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			t.shiftBoardTask(t.taskRow(), 1)
+			return nil
+		}},
+		{name: "next-lane", desc: "Select the first task of the next lane", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			return t.nextLane(1)
+		}},
+		{name: "prev-lane", desc: "Select the first task of the previous lane", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			return t.nextLane(-1)
+		}},
+		{name: "lane-down", desc: "Move task to the next lane", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			return t.moveTaskLane(t.taskRow(), 1)
+		}},
+		{name: "lane-up", desc: "Move task to the previous lane", contexts: []context{ctxTask}, run: func(t *TUI, _ context, _ []string) error {
+			return t.moveTaskLane(t.taskRow(), -1)
+		}},
+		{name: "lane", usage: "[name]", desc: "Move task to a lane, or out of the lanes", contexts: []context{ctxTask}, run: runLane, complete: completeLane},
+		{name: "lanes", usage: "[name...]", desc: "Set the lanes of the board, or remove them", contexts: boardContexts, run: runLanes, complete: completeLane},
+		{name: "wip", usage: "[limit]", desc: "Set the WIP limit of the column, or remove it", contexts: []context{ctxColumn, ctxTask}, run: runWIP},
+		{name: "done-column", desc: "Mark the column as done, or unmark it", contexts: []context{ctxColumn, ctxTask}, run: runDoneColumn},
+		{name: "send", usage: "<column number>", desc: "Move task to column N", contexts: []context{ctxTask}, run: runSend},
+		{name: "move", usage: "[[board path/]column]", desc: "Move task to another column or board", contexts: []context{ctxTask}, run: runMove, complete: completeMove},
+	}
+}
+
+// lookupCommand returns the command with the given name or alias.
+func lookupCommand(name string) (*command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+		for _, alias := range c.aliases {
+			if alias == name {
+				return c, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// runCommand runs the named command in the given context.
+func (t *TUI) runCommand(ctx context, name string, args []string) error {
+	c, ok := lookupCommand(name)
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	if !c.availableIn(ctx) {
+		return fmt.Errorf("%s isn't available in the %s", c.name, ctx)
+	}
+	return c.run(t, ctx, args)
+}
+
+// A binding binds a key to a command.
+type binding struct {
+	key     string   // key name as returned by keyName
+	command string   // name of the bound command
+	args    []string // arguments passed to the command
+}
+
+// defaultBindings holds the key bindings of each context.
+var defaultBindings = map[context][]binding{
+	ctxGlobal: {
+		{key: "q", command: "quit"},
+		{key: ":", command: "command-line"},
+		{key: "?", command: "help"},
+		{key: "z", command: "zoom"},
+		{key: "Tab", command: "switch-panel"},
+		{key: "/", command: "search"},
+		{key: "n", command: "next-match"},
+		{key: "N", command: "prev-match"},
+		{key: "f", command: "filter"},
+		{key: "Ctrl+P", command: "find-board"},
+	},
+	ctxList: {
+		{key: "a", command: "add"},
+		{key: "e", command: "edit"},
+		{key: "x", command: "toggle-done"},
+		{key: "y", command: "yank"},
+		{key: "d", command: "delete"},
+		{key: "p", command: "paste"},
+		{key: "Space", command: "toggle-desc"},
+		{key: "E", command: "edit-desc"},
+		{key: "i", command: "details"},
+		{key: "J", command: "move-down"},
+		{key: "K", command: "move-up"},
+		{key: "T", command: "move-top"},
+		{key: "B", command: "move-bottom"},
+		{key: "s", command: "sort"},
+	},
+	ctxTree: {
+		{key: "L", command: "enter"},
+		{key: "h", command: "scroll-left"},
+		{key: "l", command: "scroll-right"},
+		{key: "F", command: "focus-subtree"},
+		{key: "a", command: "add"},
+		{key: "e", command: "edit"},
+		{key: "y", command: "yank"},
+		{key: "d", command: "delete"},
+		{key: "p", command: "paste"},
+	},
+	ctxBoard: {
+		{key: "h", command: "left"},
+		{key: "l", command: "right"},
+		{key: "H", command: "back"},
+		{key: "b", command: "breadcrumb"},
+		{key: "0", command: "first-column"},
+		{key: "$", command: "last-column"},
+		{key: "[", command: "page-left"},
+		{key: "]", command: "page-right"},
+		{key: "c", command: "collapse"},
+	},
+	ctxColumn: {
+		{key: "j", command: "down"},
+		{key: "a", command: "add"},
+		{key: "e", command: "edit"},
+		{key: "y", command: "yank"},
+		{key: "d", command: "delete"},
+		{key: "p", command: "paste"},
+		{key: "s", command: "sort"},
+	},
+	ctxTask: {
+		{key: "k", command: "up"},
+		{key: "L", command: "enter"},
+		{key: "Enter", command: "cycle"},
+		{key: "Shift+Enter", command: "shift-left"},
+		{key: "<", command: "shift-left"},
+		{key: ">", command: "shift-right"},
+		{key: "1", command: "send", args: []string{"1"}},
+		{key: "2", command: "send", args: []string{"2"}},
+		{key: "3", command: "send", args: []string{"3"}},
+		{key: "4", command: "send", args: []string{"4"}},
+		{key: "5", command: "send", args: []string{"5"}},
+		{key: "6", command: "send", args: []string{"6"}},
+		{key: "7", command: "send", args: []string{"7"}},
+		{key: "8", command: "send", args: []string{"8"}},
+		{key: "9", command: "send", args: []string{"9"}},
+		{key: "a", command: "add"},
+		{key: "e", command: "edit"},
+		{key: "y", command: "yank"},
+		{key: "d", command: "delete"},
+		{key: "p", command: "paste"},
+		{key: "Space", command: "toggle-desc"},
+		{key: "E", command: "edit-desc"},
+		{key: "i", command: "details"},
+		{key: "m", command: "move"},
+		{key: "J", command: "move-down"},
+		{key: "K", command: "move-up"},
+		{key: "T", command: "move-top"},
+		{key: "B", command: "move-bottom"},
+		{key: "}", command: "next-lane"},
+		{key: "{", command: "prev-lane"},
+		{key: ")", command: "lane-down"},
+		{key: "(", command: "lane-up"},
+	},
+}
+
+// keyContexts returns the contexts whose bindings apply, in order, to
+// a key pressed in the given context. Board level bindings apply to the
+// columns and tasks of a board, and column bindings apply to a board
+// without columns, where only commands available for boards run.
+func keyContexts(ctx context) []context {
+	switch ctx {
+	case ctxColumn, ctxTask:
+		return []context{ctxBoard, ctx}
+	case ctxBoard:
+		return []context{ctxBoard, ctxColumn}
+	}
+	return []context{ctx}
+}
+
+// keyName returns the name of the key event used by key bindings: the
+// character for runes, "Space" for the space bar and the tcell key name,
+// such as "Enter" or "Ctrl+P", otherwise.
+func keyName(event *tcell.EventKey) string {
+	if event.Key() == tcell.KeyRune {
+		name := string(event.Rune())
+		if event.Rune() == ' ' {
+			name = "Space"
+		}
+		if event.Modifiers()&tcell.ModAlt != 0 {
+			name = "Alt+" + name
+		}
+		return name
+	}
+	return strings.Replace(event.Name(), "Ctrl-", "Ctrl+", 1)
+}
+
+// dispatch runs the command bound to the key event in the given context
+// and reports whether a command ran. Errors are shown in the status bar.
+func (t *TUI) dispatch(event *tcell.EventKey, ctx context) bool {
+	key := keyName(event)
+	for _, c := range keyContexts(ctx) {
+		b, ok := t.bindings[c][key]
+		if !ok {
+			continue
+		}
+		cmd, ok := lookupCommand(b.command)
+		if !ok || !cmd.availableIn(ctx) {
+			continue
+		}
+		if err := cmd.run(t, ctx, b.args); err != nil {
+			t.errorf("%s: %v", cmd.name, err)
+		}
+		return true
+	}
+	return false
+}
+
+// focusContext returns the context of the focused widget.
+func (t *TUI) focusContext() context {
+	// HasFocus doesn't lock the application, so this is safe to call
+	// while drawing.
+	switch {
+	case t.list.HasFocus():
+		return ctxList
+	case t.tree.HasFocus():
+		return ctxTree
+	}
+	if t.focusedPanel == t.leftPanel {
+		return ctxList
+	}
+	if t.isEmptyTable || len(t.boardCols) == 0 {
+		return ctxBoard
+	}
+	if t.boardColsData[t.focusedCol].GetCollapsed() {
+		return ctxColumn
+	}
+	if rows, _ := t.boardCols[t.focusedCol].GetSelectable(); rows {
+		return ctxTask
+	}
+	return ctxColumn
+}
+
+// listIdx returns the index of the selected todo list task.
+func (t *TUI) listIdx() int {
+	row, _ := t.list.GetSelection()
+	return t.calcTaskIdx(row, t.leftPanelWidth)
+}
+
+// taskRow returns the selected row of the focused board column.
+func (t *TUI) taskRow() int {
+	row, _ := t.boardCols[t.focusedCol].GetSelection()
+	return row
+}
+
+// boardTaskIdx returns the index of the selected task of the focused
+// board column.
+func (t *TUI) boardTaskIdx() int {
+	lineWidth := t.colWidth()
+	return t.calcTaskIdxBoard(t.taskRow(), lineWidth)
+}
+
+// currentBoard returns the displayed board.
+func (t *TUI) currentBoard() (*tasks.Board, error) {
+	board, ok := t.getBoardRef(t.tree.GetCurrentNode())
+	if !ok {
+		return nil, errors.New("current tree view node isn't of type Board")
+	}
+	return board, nil
+}
+
+// refreshBoardNode rebuilds the tree view nodes beneath the displayed
+// board.
+func (t *TUI) refreshBoardNode() {
+	node := t.tree.GetCurrentNode()
+	if board, ok := t.getBoardRef(node); ok {
+		node.ClearChildren()
+		t.addBoardToTree(node, board)
+	}
+}
+
+// forwardKey hands a key press to the focused primitive, as if the key
+// had been pressed, so that commands can reuse the navigation of the
+// tview primitives.
+func (t *TUI) forwardKey(key tcell.Key) {
+	handler := t.app.GetFocus().InputHandler()
+	if handler == nil {
+		return
+	}
+	handler(tcell.NewEventKey(key, 0, tcell.ModNone), func(p tview.Primitive) {
+		t.app.SetFocus(p)
+	})
+}
+
+func runDown(t *TUI, ctx context, _ []string) error {
+	if ctx == ctxColumn {
+		if col := t.boardColsData[t.focusedCol]; col.GetCollapsed() {
+			return fmt.Errorf("column %q is collapsed", col.GetTitle())
+		}
+		// Enable task selection, starting at the first task.
+		t.boardCols[t.focusedCol].SetSelectable(true, false)
+		t.boardCols[t.focusedCol].Select(t.firstTaskRow(), 0)
+		return nil
+	}
+	t.forwardKey(tcell.KeyDown)
+	return nil
+}
+
+func runUp(t *TUI, ctx context, _ []string) error {
+	if ctx == ctxTask && t.taskRow() <= t.firstTaskRow() {
+		// Disable task selection
+		t.boardCols[t.focusedCol].SetSelectable(false, false)
+	}
+	t.forwardKey(tcell.KeyUp)
+	return nil
+}
+
+func runAdd(t *TUI, ctx context, _ []string) error {
+	switch ctx {
+	case ctxList:
+		t.showModal(t.createListForm(t.listIdx()))
+	case ctxTree:
+		// Only the root node can create a new root board.
+		if t.nodeLevel(t.tree.GetCurrentNode()) == 0 {
+			t.showModal(t.createRootBoardForm())
+		}
+	case ctxBoard, ctxColumn:
+		form, err := t.createColForm(t.focusedCol)
+		if err != nil {
+			return fmt.Errorf("failed to add a column to the board: %v", err)
+		}
+		t.showModal(form)
+	case ctxTask:
+		t.showModal(t.createBoardTaskForm(t.boardTaskIdx()))
+	}
+	return nil
+}
+
+func runEdit(t *TUI, ctx context, _ []string) error {
+	switch ctx {
+	case ctxList:
+		form, err := t.editListForm(t.listIdx())
+		if err != nil {
+			return err
+		}
+		t.showModal(form)
+	case ctxTree:
+		// Only root boards are edited from the tree view.
+		node := t.tree.GetCurrentNode()
+		if t.nodeLevel(node) == 1 {
+			board, ok := t.getBoardRef(node)
+			if !ok {
+				return errors.New("current tree view node isn't of type Board")
+			}
+			t.showModal(t.editRootBoardForm(board, node))
+		}
+	case ctxColumn:
+		t.showModal(t.editColForm())
+	case ctxTask:
+		form, err := t.editBoardTaskForm(t.boardTaskIdx())
+		if err != nil {
+			return err
+		}
+		t.showModal(form)
+	}
+	return nil
+}
+
+func runYank(t *TUI, ctx context, _ []string) error {
+	switch ctx {
+	case ctxList:
+		return t.yankListTask(t.listIdx())
+	case ctxTree:
+		t.yankRootBoard()
+	case ctxColumn:
+		t.yankBoardCol()
+	case ctxTask:
+		t.yankBoardTask(t.taskRow())
+	}
+	return nil
+}
+
+// runDelete deletes the selected item after confirming it. Tasks
+// without a sub-board are deleted right away if so configured.
+func runDelete(t *TUI, ctx context, _ []string) error {
+	text, leaf, err := t.deleteConfirmation(ctx)
+	if err != nil {
+		return err
+	}
+	// Read the selection now, as the confirmation takes the focus.
+	var del func()
+	switch ctx {
+	case ctxList:
+		idx := t.listIdx()
+		del = func() {
+			if err := t.deleteListTask(idx); err != nil {
+				t.errorf("Failed to delete task: %v", err)
+			}
+		}
+	case ctxTree:
+		del = t.deleteRootBoard
+	case ctxColumn:
+		del = t.removeBoardCol
+	case ctxTask:
+		row := t.taskRow()
+		del = func() { t.removeBoardTask(row) }
+	}
+	if leaf && t.skipLeafConfirm {
+		del()
+		return nil
+	}
+	t.confirm(text, "Delete", del)
+	return nil
+}
+
+func runPaste(t *TUI, ctx context, _ []string) error {
+	switch ctx {
+	case ctxList:
+		t.pasteListTask(t.listIdx())
+	case ctxTree:
+		t.pasteRootBoard()
+	case ctxColumn:
+		t.pasteBoardCol()
+	case ctxTask:
+		t.pasteBoardTask(t.taskRow())
+	}
+	return nil
+}
+
+func runToggleDesc(t *TUI, ctx context, _ []string) error {
+	if ctx == ctxList {
+		return t.toggleTaskDesc(t.listIdx())
+	}
+	t.toggleBoardTaskDesc(t.taskRow())
+	return nil
+}
+
+// reorder returns a command function moving the selected task the
+// given number of places down, or up if negative, past the tasks shown
+// next to it.
+func reorder(offset int) func(t *TUI, ctx context, args []string) error {
+	return func(t *TUI, ctx context, _ []string) error {
+		if ctx == ctxList {
+			idx := t.listIdx()
+			list := t.taskData.GetTasks()
+			to := shownNeighbour(len(list), idx, offset, func(i int) bool {
+				return t.listTaskVisible(list[i])
+			})
+			if to == idx {
+				return nil
+			}
+			return t.moveListTask(idx, to)
+		}
+		return t.reorderBoardTask(t.taskRow(), offset)
+	}
+}
+
+// reorderEnd returns a command function moving the selected task to
+// the bottom, or to the top if dir is negative.
+func reorderEnd(dir int) func(t *TUI, ctx context, args []string) error {
+	return func(t *TUI, ctx context, _ []string) error {
+		if ctx == ctxList {
+			to := 0
+			if dir > 0 {
+				to = len(t.taskData.GetTasks()) - 1
+			}
+			return t.moveListTask(t.listIdx(), to)
+		}
+		return t.reorderBoardTask(t.taskRow(), dir*len(t.boardColsData[t.focusedCol].GetTasks()))
+	}
+}
+
+func runRename(t *TUI, ctx context, args []string) error {
+	name := strings.Join(args, " ")
+	if name == "" {
+		return errors.New("usage: rename <name>")
+	}
+	switch ctx {
+	case ctxList:
+		task, err := t.taskData.GetTask(t.listIdx())
+		if err != nil {
+			return err
+		}
+		task.SetName(name)
+		t.filterAndUpdateList(t.leftPanelWidth)
+	case ctxTree:
+		node := t.tree.GetCurrentNode()
+		board, ok := t.getBoardRef(node)
+		if !ok {
+			return errors.New("only boards can be renamed in the tree view")
+		}
+		board.SetTitle(name)
+		t.setExpanded(node, node.IsExpanded())
+	case ctxBoard:
+		board, err := t.currentBoard()
+		if err != nil {
+			return err
+		}
+		board.SetTitle(name)
+		t.setExpanded(t.tree.GetCurrentNode(), t.tree.GetCurrentNode().IsExpanded())
+	case ctxColumn:
+		t.boardColsData[t.focusedCol].SetTitle(name)
+		t.updateColumn(t.focusedCol)
+		t.refreshBoardNode()
+	case ctxTask:
+		task, err := t.boardColsData[t.focusedCol].GetTask(t.boardTaskIdx())
+		if err != nil {
+			return err
+		}
+		task.SetName(name)
+		t.updateColumn(t.focusedCol)
+		t.refreshBoardNode()
+	}
+	return nil
+}
+
+func runSort(t *TUI, ctx context, args []string) error {
+	var get func() string
+	var set func(name string) error
+	switch ctx {
+	case ctxList:
+		get = t.taskData.GetPrioritizer
+		set = func(name string) error {
+			if err := t.taskData.SetPrioritizer(name); err != nil {
+				return err
+			}
+			t.filterAndUpdateList(t.leftPanelWidth)
+			return nil
+		}
+	default:
+		col := &t.boardColsData[t.focusedCol]
+		colIdx := t.focusedCol
+		get = col.GetPrioritizer
+		set = func(name string) error {
+			if err := col.SetPrioritizer(name); err != nil {
+				return err
+			}
+			t.updateColumn(colIdx)
+			return nil
+		}
+	}
+	if len(args) > 0 {
+		return set(args[0])
+	}
+	t.pickPrioritizer(get(), func(name string) { set(name) })
+	return nil
+}
+
+func completeSort(_ *TUI, arg string) []string {
+	return completeWords(tasks.Prioritizers(), arg)
+}
+
+func runArchive(t *TUI, ctx context, _ []string) error {
+	if ctx == ctxList {
+		t.taskData.ArchiveDone()
+		t.filterAndUpdateList(t.leftPanelWidth)
+		return nil
+	}
+	board, err := t.currentBoard()
+	if err != nil {
+		return err
+	}
+	if ctx == ctxColumn {
+		t.treeData.ArchiveColumn(board, t.focusedCol)
+	} else if err := t.treeData.ArchiveTask(board, t.focusedCol, t.boardTaskIdx()); err != nil {
+		return err
+	}
+	t.updateColumn(t.focusedCol)
+	t.refreshBoardNode()
+	return nil
+}
+
+func runNewBoard(t *TUI, ctx context, args []string) error {
+	title := strings.Join(args, " ")
+	if title == "" {
+		return errors.New("usage: newboard <title>")
+	}
+	if ctx != ctxTask {
+		board := t.treeData.NewBoard(title)
+		t.treeData.AddRoot(board)
+		t.addRootBoardToTree(board)
+		return nil
+	}
+
+	task, err := t.boardColsData[t.focusedCol].GetTask(t.boardTaskIdx())
+	if err != nil {
+		return err
+	}
+	if task.GetHasChild() {
+		return fmt.Errorf("task %q already has a sub-board", task.GetName())
+	}
+	if err := t.createAndAddChildBoard(title, task); err != nil {
+		return err
+	}
+	t.updateColumn(t.focusedCol)
+	t.refreshBoardNode()
+	return nil
+}
+
+func runEnter(t *TUI, ctx context, _ []string) error {
+	if ctx == ctxTask {
+		t.enterSubBoard(t.taskRow())
+		return nil
+	}
+	node := t.tree.GetCurrentNode()
+	board, ok := t.getBoardRef(node)
+	if !ok {
+		return nil
+	}
+	t.showBoard(board)
+	t.push(node)
+	return nil
+}
+
+func runLeft(t *TUI, _ context, _ []string) error {
+	// If at the first column, switch back to TreeView
+	if t.isEmptyTable || t.focusedCol == 0 {
+		if !t.isEmptyTable {
+			t.boardCols[t.focusedCol].SetSelectable(false, false)
+		}
+		t.showTreeView()
+		return nil
+	}
+	t.focusColumn(t.focusedCol - 1)
+	return nil
+}
+
+func runSend(t *TUI, _ context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: send <column number>")
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid column number %q", args[0])
+	}
+	t.sendBoardTask(t.taskRow(), n-1)
+	return nil
+}
+
+// runMove moves the selected task. Without arguments it opens the
+// destination picker. Otherwise the argument names a column of the
+// displayed board, or a board path and column separated by a slash.
+func runMove(t *TUI, _ context, args []string) error {
+	if len(args) == 0 {
+		t.pickTaskDestination(t.taskRow())
+		return nil
+	}
+	board, err := t.currentBoard()
+	if err != nil {
+		return err
+	}
+	dst, colName := board, strings.Join(args, " ")
+	if i := strings.LastIndex(colName, "/"); i >= 0 {
+		path := colName[:i]
+		colName = colName[i+1:]
+		dst = nil
+		for _, b := range t.treeData.Boards() {
+			if strings.EqualFold(t.treeData.PathString(b.GetID()), path) {
+				dst = b
+				break
+			}
+		}
+		if dst == nil {
+			return fmt.Errorf("no board %q", path)
+		}
+	}
+	dstCol := -1
+	for i, c := range dst.GetColumns() {
+		if strings.EqualFold(c.GetTitle(), colName) {
+			dstCol = i
+		}
+	}
+	if n, err := strconv.Atoi(colName); dstCol < 0 && err == nil && n >= 1 && n <= len(dst.GetColumns()) {
+		dstCol = n - 1
+	}
+	if dstCol < 0 {
+		return fmt.Errorf("board %q has no column %q", dst.GetTitle(), colName)
+	}
+
+	if dst == board {
+		t.sendBoardTask(t.taskRow(), dstCol)
+		return nil
+	}
+	if err := t.checkWIP(&dst.Columns[dstCol]); err != nil {
+		return err
+	}
+	srcCol := t.focusedCol
+	if err := t.treeData.MoveTask(board, srcCol, t.boardTaskIdx(), dst, dstCol, 0); err != nil {
+		return err
+	}
+	t.updateColumn(srcCol)
+	t.reloadTree(board)
+	t.completeParents(board, dst)
+	return nil
+}
+
+// completeMove completes the columns of the displayed board and the
+// columns of every board prefixed by the board path.
+func completeMove(t *TUI, arg string) []string {
+	var words []string
+	for _, col := range t.boardColsData {
+		words = append(words, col.GetTitle())
+	}
+	for _, b := range t.treeData.Boards() {
+		path := t.treeData.PathString(b.GetID())
+		for _, col := range b.GetColumns() {
+			words = append(words, path+"/"+col.GetTitle())
+		}
+	}
+	return completeWords(words, arg)
+}
+
+func runFilter(t *TUI, _ context, args []string) error {
+	if len(args) == 0 {
+		t.showFilterBar()
+		return nil
+	}
+	if err := t.setFilter(strings.Join(args, " ")); err != nil {
+		return err
+	}
+	if t.filter != nil {
+		t.layout.ResizeItem(t.filterBar, 1, 0)
+	} else {
+		t.layout.ResizeItem(t.filterBar, 0, 0)
+	}
+	return nil
+}
+
+// completeFilter completes the names of saved queries.
+func completeFilter(t *TUI, arg string) []string {
+	var words []string
+	for _, q := range t.queries.Queries {
+		words = append(words, "@"+q.Name)
+	}
+	return completeWords(words, arg)
+}
+
+// completeWords returns the words starting with the given prefix,
+// ignoring case.
+func completeWords(words []string, prefix string) []string {
+	var matches []string
+	for _, w := range words {
+		if strings.HasPrefix(strings.ToLower(w), strings.ToLower(prefix)) {
+			matches = append(matches, w)
+		}
+	}
+	return matches
+}
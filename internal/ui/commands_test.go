@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func Example_keyName() {
+	fmt.Println(keyName(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone)))
+	fmt.Println(keyName(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)))
+	fmt.Println(keyName(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModShift)))
+	fmt.Println(keyName(tcell.NewEventKey(tcell.KeyCtrlP, 0, tcell.ModNone)))
+	fmt.Println(keyName(tcell.NewEventKey(tcell.KeyCtrlP, 0, tcell.ModCtrl)))
+
+	// Output:
+	// a
+	// Space
+	// Shift+Enter
+	// Ctrl+P
+	// Ctrl+P
+}
+
+func ExampleTUI_completions() {
+	t := new(TUI)
+	fmt.Println(t.completions(ctxList, "move"))
+	fmt.Println(t.completions(ctxTree, "move"))
+	fmt.Println(t.completions(ctxColumn, "sort d"))
+
+	// Output:
+	// [move-bottom move-down move-top move-up]
+	// []
+	// [sort due]
+}
+
+// TestDefaultBindings checks that every default key binding runs a
+// registered command available in the context it is bound in.
+func TestDefaultBindings(t *testing.T) {
+	for ctx, bindings := range defaultBindings {
+		seen := make(map[string]bool)
+		for _, b := range bindings {
+			if seen[b.key] {
+				t.Errorf("%s: key %q bound twice", ctx, b.key)
+			}
+			seen[b.key] = true
+
+			c, ok := lookupCommand(b.command)
+			if !ok {
+				t.Errorf("%s: key %q bound to unknown command %q", ctx, b.key, b.command)
+				continue
+			}
+			if !c.availableIn(ctx) {
+				t.Errorf("%s: key %q bound to %q, which isn't available there", ctx, b.key, b.command)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// confirm opens a modal asking to confirm an action and calls yes if it
+// is confirmed. The action label names the confirming button, which is
+// also pressed by y, while n and Escape cancel. Cancel is focused by
+// default so that a stray Enter doesn't confirm.
+func (t *TUI) confirm(text, action string, yes func()) {
+	prevFocus := t.app.GetFocus()
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{action, "Cancel"})
+	if t.theme.Monochrome {
+		modal.SetButtonStyle(tcell.StyleDefault).
+			SetButtonActivatedStyle(tcell.StyleDefault.Reverse(true))
+	}
+	done := func(confirmed bool) {
+		t.pages.RemovePage("confirm")
+		t.app.SetFocus(prevFocus)
+		if confirmed {
+			yes()
+		}
+	}
+	modal.SetDoneFunc(func(_ int, label string) { done(label == action) })
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'y':
+			done(true)
+			return nil
+		case 'n':
+			done(false)
+			return nil
+		}
+		return event
+	})
+	modal.SetFocus(1)
+	t.pages.AddPage("confirm", modal, false, true)
+	t.app.SetFocus(modal)
+}
+
+// deleteConfirmation returns the confirmation text for deleting the
+// selected item of a context, listing the tasks and sub-boards removed
+// along with it, and whether the item is a task without a sub-board.
+func (t *TUI) deleteConfirmation(ctx context) (text string, leaf bool, err error) {
+	switch ctx {
+	case ctxList:
+		idx := t.listIdx()
+		if idx < 0 || idx >= len(t.taskData.Tasks) {
+			return "", false, errors.New("no task selected")
+		}
+		return fmt.Sprintf("Delete task %q?", t.taskData.Tasks[idx].GetName()), true, nil
+	case ctxTree:
+		node := t.tree.GetCurrentNode()
+		board, ok := t.getBoardRef(node)
+		if !ok || t.nodeLevel(node) != 1 {
+			return "", false, errors.New("only root boards can be deleted from the tree view")
+		}
+		n, m := t.treeData.CountBoard(board)
+		return DeleteText("board", board.GetTitle(), n, m), false, nil
+	case ctxColumn:
+		col := t.boardColsData[t.focusedCol]
+		n, m := t.treeData.CountColumn(col)
+		return DeleteText("column", col.GetTitle(), n, m), false, nil
+	case ctxTask:
+		task, err := t.boardColsData[t.focusedCol].GetTask(t.boardTaskIdx())
+		if err != nil {
+			return "", false, errors.New("no task selected")
+		}
+		if !task.GetHasChild() {
+			return fmt.Sprintf("Delete task %q?", task.GetName()), true, nil
+		}
+		n, m := t.treeData.CountTask(*task)
+		return DeleteText("task", task.GetName(), n-1, m), false, nil
+	}
+	return "", false, fmt.Errorf("nothing to delete in the %s", ctx)
+}
+
+// DeleteText returns the confirmation text for deleting an item that
+// holds the given number of tasks and sub-boards. It is shared with the
+// delete command of the CLI.
+func DeleteText(kind, name string, tasks, boards int) string {
+	var contents []string
+	if tasks > 0 {
+		contents = append(contents, plural(tasks, "task"))
+	}
+	if boards > 0 {
+		contents = append(contents, plural(boards, "sub-board"))
+	}
+	if len(contents) == 0 {
+		return fmt.Sprintf("Delete %s %q?", kind, name)
+	}
+	return fmt.Sprintf("Delete %s %q and its %s?", kind, name, strings.Join(contents, " and "))
+}
+
+// plural returns the count followed by the word, in plural unless the
+// count is one.
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
@@ -0,0 +1,14 @@
+package ui
+
+import "fmt"
+
+func ExampleDeleteText() {
+	fmt.Println(DeleteText("column", "Done", 0, 0))
+	fmt.Println(DeleteText("task", "api", 1, 1))
+	fmt.Println(DeleteText("board", "Project", 12, 3))
+
+	// Output:
+	// Delete column "Done"?
+	// Delete task "api" and its 1 task and 1 sub-board?
+	// Delete board "Project" and its 12 tasks and 3 sub-boards?
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// detailsWidth is the width of the task details pane, with its border.
+const detailsWidth = 60
+
+// runDetails shows the selected task in a pane over the panels: its due
+// date, its description rendered from Markdown and its checklist. The
+// pane scrolls and is closed with Escape, q or i.
+func runDetails(t *TUI, ctx context, _ []string) error {
+	task, err := t.selectedTask(ctx)
+	if err != nil {
+		return err
+	}
+	prevFocus := t.app.GetFocus()
+
+	var lines []string
+	if due := formatDue(task.GetDue()); due != "" {
+		lines = append(lines, "Due "+due, "")
+	}
+	details := taskDetails(task, detailsWidth-borderWidth, t.theme.codeColor())
+	if len(details) == 0 {
+		details = []string{"No description"}
+	}
+	lines = append(lines, details...)
+
+	table := tview.NewTable().
+		SetSelectable(false, false)
+	table.SetBorder(true)
+	table.SetTitle(tview.Escape(task.GetName() + checklistBadge(task)))
+	for i, line := range lines {
+		table.SetCell(i, 0, tview.NewTableCell(line).
+			SetExpansion(1).
+			SetStyle(t.theme.descStyle()))
+	}
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' || event.Rune() == 'i' {
+			t.pages.RemovePage("details")
+			t.app.SetFocus(prevFocus)
+			return nil
+		}
+		return event
+	})
+
+	pane := tview.NewGrid().
+		SetColumns(0, detailsWidth, 0).
+		SetRows(1, 0, 1).
+		AddItem(table, 1, 1, 1, 1, 0, 0, true)
+	t.pages.AddPage("details", pane, true, true)
+	t.app.SetFocus(table)
+	return nil
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// editText returns the given text after editing it in the editor named
+// by the EDITOR environment variable, with the TUI suspended.
+func (t *TUI) editText(editor, text string) (string, error) {
+	f, err := os.CreateTemp("", "bp-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	// The editor may be given with arguments, such as "code --wait".
+	args := append(strings.Fields(editor), f.Name())
+	var runErr error
+	t.app.Suspend(func() {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		runErr = cmd.Run()
+	})
+	if runErr != nil {
+		return "", fmt.Errorf("editor %q failed: %v", editor, runErr)
+	}
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\n"), nil
+}
+
+// runEditDesc edits the description of the selected task in $EDITOR, or
+// in the task form if EDITOR isn't set.
+func runEditDesc(t *TUI, ctx context, _ []string) error {
+	task, err := t.selectedTask(ctx)
+	if err != nil {
+		return err
+	}
+	editor := strings.TrimSpace(os.Getenv("EDITOR"))
+	if editor == "" {
+		var form *tview.Form
+		if ctx == ctxList {
+			form, err = t.editListForm(t.listIdx())
+		} else {
+			form, err = t.editBoardTaskForm(t.boardTaskIdx())
+		}
+		if err != nil {
+			return err
+		}
+		form.SetFocus(form.GetFormItemIndex("Description"))
+		t.showModal(form)
+		return nil
+	}
+	desc, err := t.editText(editor, task.GetDesc())
+	if err != nil {
+		return err
+	}
+	if desc == task.GetDesc() {
+		t.infof("Description unchanged")
+		return nil
+	}
+	task.SetDesc(desc)
+	t.refreshTask(ctx, task)
+	return nil
+}
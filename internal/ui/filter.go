@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// filterLabel is the filter bar label shown when there is no error.
+const filterLabel = "Filter: "
+
+// SetQueries sets the saved queries available to the filter bar. It
+// must be called before [TUI.Init].
+func (t *TUI) SetQueries(q *tasks.SavedQueries) { t.queries = q }
+
+// initFilterBar initializes the filter bar.
+//
+// The filter bar accepts a query, which narrows the todo list and the
+// displayed board to the matching tasks. Entering "@name" applies the
+// query saved under that name, while "@name=query" saves the query
+// under that name and applies it. Entering an empty query clears the
+// filter.
+func (t *TUI) initFilterBar() {
+	if t.queries == nil {
+		t.queries = new(tasks.SavedQueries)
+	}
+	t.filterBar = tview.NewInputField().
+		SetLabel(filterLabel).
+		SetFieldWidth(0)
+	t.filterBar.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			if err := t.setFilter(t.filterBar.GetText()); err != nil {
+				t.filterBar.SetLabel(fmt.Sprintf("Filter (%v): ", err))
+				return
+			}
+		case tcell.KeyEscape:
+			// Discard the edit and restore the active filter.
+			if t.filter != nil {
+				t.filterBar.SetText(t.filter.Source)
+			} else {
+				t.filterBar.SetText("")
+			}
+		default:
+			return
+		}
+		t.filterBar.SetLabel(filterLabel)
+		t.hideFilterBar()
+	})
+}
+
+// showFilterBar shows the filter bar and focuses it.
+func (t *TUI) showFilterBar() {
+	t.layout.ResizeItem(t.filterBar, 1, 0)
+	t.app.SetFocus(t.filterBar)
+}
+
+// hideFilterBar returns focus to the focused panel. The filter bar stays
+// visible while a filter is active.
+func (t *TUI) hideFilterBar() {
+	if t.filter == nil {
+		t.layout.ResizeItem(t.filterBar, 0, 0)
+	}
+	switch t.focusedPanel {
+	case t.leftPanel:
+		t.app.SetFocus(t.list)
+	default:
+		t.app.SetFocus(t.rightPanel)
+		if len(t.boardCols) > 0 && t.focusedCol < len(t.boardCols) {
+			t.app.SetFocus(t.boardCols[t.focusedCol])
+		}
+	}
+}
+
+// setFilter parses and applies the given filter bar input.
+func (t *TUI) setFilter(input string) error {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "@") {
+		name, query, save := strings.Cut(input[1:], "=")
+		name = strings.TrimSpace(name)
+		if save {
+			query = strings.TrimSpace(query)
+			if err := t.queries.Save(name, query); err != nil {
+				return err
+			}
+		} else {
+			var ok bool
+			if query, ok = t.queries.Get(name); !ok {
+				return fmt.Errorf("no query saved as %q", name)
+			}
+		}
+		input = query
+	}
+
+	if input == "" {
+		t.filter = nil
+	} else {
+		q, err := tasks.ParseQuery(input)
+		if err != nil {
+			return err
+		}
+		t.filter = q
+	}
+	t.filterBar.SetText(input)
+	t.refreshFiltered()
+	return nil
+}
+
+// refreshFiltered redraws the todo list and the displayed board columns
+// after the active filter changed.
+func (t *TUI) refreshFiltered() {
+	t.filterAndUpdateList(t.leftPanelWidth)
+	for i := range t.boardCols {
+		if i < len(t.boardColsData) {
+			t.updateColumn(i)
+		}
+	}
+}
+
+// listTaskVisible reports whether a todo list task passes the active
+// filter.
+func (t *TUI) listTaskVisible(task tasks.TodoTask) bool {
+	if t.filter == nil {
+		return true
+	}
+	return t.filter.Match(task.Target())
+}
+
+// boardTaskVisible reports whether a task of the given column of the
+// displayed board passes the active filter.
+func (t *TUI) boardTaskVisible(colIdx int, task tasks.BoardTask) bool {
+	if t.filter == nil {
+		return true
+	}
+	board, err := t.treeData.GetBoard(t.treeData.GetCurrentBoardID())
+	if err != nil {
+		return true
+	}
+	return t.filter.Match(t.treeData.Target(board, colIdx, task))
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+// showBoardFinder opens a picker of every board in the board tree,
+// matched by title and path, and opens the selected board.
+func (t *TUI) showBoardFinder() {
+	var items []pickerItem
+	for _, b := range t.treeData.Boards() {
+		items = append(items, pickerItem{
+			Text: t.treeData.PathString(b.GetID()),
+			Ref:  b,
+		})
+	}
+	t.showPicker("Go To Board", items, func(item pickerItem) {
+		if err := t.openBoard(item.Ref.(*tasks.Board)); err != nil {
+			t.errorf("Failed to open board: %v", err)
+		}
+	})
+}
+
+// openBoard shows the given board in the right panel and focuses it.
+// The tree view node of the board is made current and the navigation
+// stack is rebuilt from the board's parent chain, so navigating back
+// walks up through the board's actual parents.
+func (t *TUI) openBoard(b *tasks.Board) error {
+	node := t.findBoardNode(b.GetID())
+	if node == nil {
+		return errors.New("couldn't find board tree view node")
+	}
+	if t.focusedPanel != t.rightPanel {
+		t.switchPanel()
+	}
+	t.revealNode(node)
+	t.tree.SetCurrentNode(node)
+	t.showBoard(b)
+	t.rebuildNavStack(node)
+	return nil
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyMatch reports whether every rune of the pattern appears in the
+// given string in order, ignoring case. The returned score is higher
+// for matches that are consecutive or start at a word boundary.
+func fuzzyMatch(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	text := []rune(s)
+	lower := []rune(strings.ToLower(s))
+
+	score := 0
+	pi := 0
+	prev := -2
+	for i := 0; i < len(lower) && pi < len(p); i++ {
+		if lower[i] != p[pi] {
+			continue
+		}
+		score++
+		// Reward consecutive matches.
+		if prev == i-1 {
+			score += 5
+		}
+		// Reward matches at the start of a word.
+		if i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1]) {
+			score += 3
+		}
+		prev = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	// Prefer shorter strings when scores are otherwise equal.
+	return score*100 - len(text), true
+}
+
+// fuzzyFilter returns the indices of the given strings that match the
+// pattern, ordered from best to worst match.
+func fuzzyFilter(pattern string, items []string) []int {
+	type match struct {
+		idx   int
+		score int
+	}
+	var matches []match
+	for i, s := range items {
+		if score, ok := fuzzyMatch(pattern, s); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indices := make([]int, len(matches))
+	for i, m := range matches {
+		indices[i] = m.idx
+	}
+	return indices
+}
@@ -0,0 +1,17 @@
+package ui
+
+import "fmt"
+
+func Example_fuzzyFilter() {
+	items := []string{
+		"Project › TODO",
+		"Project/Build PKMS › Done",
+		"Groceries › Done",
+	}
+	for _, idx := range fuzzyFilter("pkd", items) {
+		fmt.Println(items[idx])
+	}
+
+	// Output:
+	// Project/Build PKMS › Done
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// contextTitles holds the help section title of each context, in the
+// order the sections are printed.
+var contextTitles = []struct {
+	ctx   context
+	title string
+}{
+	{ctxGlobal, "Global"},
+	{ctxList, "TODO list"},
+	{ctxTree, "Tree view"},
+	{ctxBoard, "Board"},
+	{ctxColumn, "Board column"},
+	{ctxTask, "Board task"},
+}
+
+// contextTitle returns the help section title of the context.
+func contextTitle(ctx context) string {
+	for _, ct := range contextTitles {
+		if ct.ctx == ctx {
+			return ct.title
+		}
+	}
+	return string(ctx)
+}
+
+// A helpEntry describes the keys bound to a command.
+type helpEntry struct {
+	keys string // comma separated key names
+	desc string
+}
+
+// helpEntries returns the help entries of the bindings of the bind
+// context whose commands are available in the run context. Keys bound
+// to the same command are listed in a single entry, in the order of the
+// command registry, with default keys before configured ones.
+func helpEntries(bindings map[context]map[string]binding, bind, run context) []helpEntry {
+	var entries []helpEntry
+	for _, c := range commands {
+		if !c.availableIn(run) {
+			continue
+		}
+		var keys, configured []string
+		for _, b := range defaultBindings[bind] {
+			if bound, ok := bindings[bind][b.key]; ok && bound.command == c.name {
+				keys = append(keys, b.key)
+			}
+		}
+		for key, b := range bindings[bind] {
+			if b.command == c.name && !isDefaultKey(bind, key) {
+				configured = append(configured, key)
+			}
+		}
+		sort.Strings(configured)
+		keys = append(keys, configured...)
+		if len(keys) > 0 {
+			entries = append(entries, helpEntry{keys: strings.Join(keys, ", "), desc: c.desc})
+		}
+	}
+	return entries
+}
+
+// isDefaultKey reports whether the key has a default binding in the
+// context.
+func isDefaultKey(ctx context, key string) bool {
+	for _, b := range defaultBindings[ctx] {
+		if b.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// commandEntries returns the help entries of the commands available in
+// a context, as typed on the command line. Commands available in every
+// context are only included if global is true.
+func commandEntries(ctx context, global bool) []helpEntry {
+	var entries []helpEntry
+	for _, c := range commands {
+		if !c.availableIn(ctx) || c.contexts == nil && !global {
+			continue
+		}
+		names := append([]string{c.name}, c.aliases...)
+		for i := range names {
+			names[i] = ":" + names[i]
+		}
+		usage := strings.Join(names, ", ")
+		if c.usage != "" {
+			usage += " " + c.usage
+		}
+		entries = append(entries, helpEntry{keys: usage, desc: c.desc})
+	}
+	return entries
+}
+
+// PrintHelp prints the key bindings of every context, with the
+// configured keys applied, and the commands of the command line.
+func PrintHelp(w io.Writer, c *config.Config) error {
+	bindings, err := buildBindings(c.Keys)
+	if err != nil {
+		return err
+	}
+	for _, ct := range contextTitles {
+		fmt.Fprintf(w, "%s:\n", ct.title)
+		printEntries(w, helpEntries(bindings, ct.ctx, ct.ctx))
+		fmt.Fprintln(w)
+	}
+	for i, ct := range contextTitles {
+		if ct.ctx == ctxBoard {
+			continue
+		}
+		fmt.Fprintf(w, "%s commands:\n", ct.title)
+		printEntries(w, commandEntries(ct.ctx, ct.ctx == ctxGlobal))
+		if i < len(contextTitles)-1 {
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+// printEntries prints help entries as two aligned columns.
+func printEntries(w io.Writer, entries []helpEntry) {
+	width := 0
+	for _, e := range entries {
+		if len(e.keys) > width {
+			width = len(e.keys)
+		}
+	}
+	for _, e := range entries {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, e.keys, e.desc)
+	}
+}
+
+// showHelp opens an overlay listing the key bindings and commands
+// available in the focused context. Escape, q or ? close it.
+func (t *TUI) showHelp() {
+	ctx := t.focusContext()
+	prevFocus := t.app.GetFocus()
+
+	table := tview.NewTable().
+		SetSelectable(false, false)
+	table.SetBorder(true)
+	table.SetTitle("Help: " + contextTitle(ctx))
+
+	row := 0
+	section := func(title string, entries []helpEntry) {
+		if len(entries) == 0 {
+			return
+		}
+		if row > 0 {
+			row++
+		}
+		table.SetCell(row, 0, tview.NewTableCell(title).
+			SetTextColor(t.theme.ColumnHeader).
+			SetAttributes(tcell.AttrBold))
+		row++
+		for _, e := range entries {
+			table.SetCell(row, 0, tview.NewTableCell(tview.Escape(e.keys)+"  "))
+			table.SetCell(row, 1, tview.NewTableCell(e.desc).SetStyle(t.theme.descStyle()))
+			row++
+		}
+	}
+	for _, c := range keyContexts(ctx) {
+		section(contextTitle(c), helpEntries(t.bindings, c, ctx))
+	}
+	section(contextTitle(ctxGlobal), helpEntries(t.bindings, ctxGlobal, ctxGlobal))
+	section("Commands", commandEntries(ctx, true))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' || event.Rune() == '?' {
+			t.pages.RemovePage("help")
+			t.app.SetFocus(prevFocus)
+			return nil
+		}
+		return event
+	})
+
+	modal := tview.NewGrid().
+		SetColumns(0, 80, 0).
+		SetRows(1, 0, 1).
+		AddItem(table, 1, 1, 1, 1, 0, 0, true)
+	t.pages.AddPage("help", modal, true, true)
+	t.app.SetFocus(table)
+}
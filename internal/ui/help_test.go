@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ericstrs/bp/internal/config"
+)
+
+func Example_helpEntries() {
+	bindings, err := buildBindings(map[string]map[string]config.Keys{
+		"tree": {"enter": {"L", "Right"}},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	printEntries(os.Stdout, helpEntries(bindings, ctxTree, ctxTree))
+
+	// Output:
+	//   a         Add a task, board or column
+	//   e         Edit the selected item
+	//   y         Copy the selected item
+	//   d         Delete and copy the selected item
+	//   p         Paste below the selected item
+	//   h         Scroll the tree view left
+	//   l         Scroll the tree view right
+	//   F         Show only the subtree of the current node, or the whole tree again
+	//   L, Right  Enter the board or sub-board
+}
+
+func Example_commandEntries() {
+	printEntries(os.Stdout, commandEntries(ctxBoard, false))
+
+	// Output:
+	//   :add                  Add a task, board or column
+	//   :rename <name>        Rename the selected item
+	//   :back                 Go back to the previous board
+	//   :breadcrumb [levels]  Select a board of the breadcrumb, or go up a number of levels
+	//   :left                 Focus the column to the left
+	//   :right                Focus the column to the right
+	//   :page-left            Show the previous columns that don't fit
+	//   :page-right           Show the next columns that don't fit
+	//   :collapse             Collapse or expand the column
+	//   :first-column         Focus the first column
+	//   :last-column          Focus the last column
+	//   :lanes [name...]      Set the lanes of the board, or remove them
+}
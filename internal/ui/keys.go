@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ericstrs/bp/internal/config"
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetConfig applies the user configuration. Invalid or conflicting key
+// bindings and invalid themes are reported as an error. It must be
+// called before [TUI.Init].
+func (t *TUI) SetConfig(c *config.Config) error {
+	bindings, err := buildBindings(c.Keys)
+	if err != nil {
+		return err
+	}
+	theme, err := resolveTheme(c.Theme, c.Colors)
+	if err != nil {
+		return err
+	}
+	if w := c.Board.MinColumnWidth; w != 0 && w < minColWidthLimit {
+		return fmt.Errorf("min-column-width must be at least %d", minColWidthLimit)
+	}
+	switch c.Board.WIPLimits {
+	case "", "warn", "block":
+	default:
+		return fmt.Errorf("unknown wip-limits %q, want warn or block", c.Board.WIPLimits)
+	}
+	t.bindings = bindings
+	t.theme = theme
+	t.skipLeafConfirm = c.Confirm.SkipLeafTasks
+	t.minColWidth = c.Board.MinColumnWidth
+	t.blockWIP = c.Board.WIPLimits == "block"
+	t.autoComplete = c.Board.CompleteParents
+	return nil
+}
+
+// initBindings sets the default key bindings, unless the configuration
+// already set them.
+func (t *TUI) initBindings() {
+	if t.bindings == nil {
+		t.bindings, _ = buildBindings(nil)
+	}
+}
+
+// buildBindings indexes the default key bindings of each context by key
+// name after applying the configured keys. Configuring an action, a
+// command name optionally followed by arguments, replaces the default
+// keys of that action in the context.
+func buildBindings(keys map[string]map[string]config.Keys) (map[context]map[string]binding, error) {
+	var errs []string
+	merged := make(map[context][]binding)
+	for ctx, bindings := range defaultBindings {
+		merged[ctx] = append([]binding(nil), bindings...)
+	}
+
+	for name, actions := range keys {
+		ctx := context(name)
+		if _, ok := defaultBindings[ctx]; !ok {
+			errs = append(errs, fmt.Sprintf("unknown context %q", name))
+			continue
+		}
+		for action, names := range actions {
+			fields := strings.Fields(action)
+			if len(fields) == 0 {
+				errs = append(errs, fmt.Sprintf("%s: empty action", ctx))
+				continue
+			}
+			c, ok := lookupCommand(fields[0])
+			if !ok {
+				errs = append(errs, fmt.Sprintf("%s: unknown action %q", ctx, action))
+				continue
+			}
+			if !c.availableIn(ctx) {
+				errs = append(errs, fmt.Sprintf("%s: action %q isn't available in this context", ctx, action))
+				continue
+			}
+			args := fields[1:]
+
+			// Drop the default keys of the action.
+			kept := merged[ctx][:0]
+			for _, b := range merged[ctx] {
+				if b.command != c.name || strings.Join(b.args, " ") != strings.Join(args, " ") {
+					kept = append(kept, b)
+				}
+			}
+			merged[ctx] = kept
+
+			for _, n := range names {
+				key, err := canonicalKey(n)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %s: %v", ctx, action, err))
+					continue
+				}
+				merged[ctx] = append(merged[ctx], binding{key: key, command: c.name, args: args})
+			}
+		}
+	}
+
+	bindings := make(map[context]map[string]binding)
+	for ctx, bs := range merged {
+		bindings[ctx] = make(map[string]binding)
+		for _, b := range bs {
+			if prev, ok := bindings[ctx][b.key]; ok {
+				errs = append(errs, fmt.Sprintf("%s: key %q is bound to both %q and %q", ctx, b.key, prev.action(), b.action()))
+				continue
+			}
+			bindings[ctx][b.key] = b
+		}
+	}
+	errs = append(errs, conflicts(bindings)...)
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, errors.New("invalid key bindings:\n\t" + strings.Join(errs, "\n\t"))
+	}
+	return bindings, nil
+}
+
+// conflicts returns a description of every key that is bound in two
+// contexts whose bindings apply to the same key press, where the first
+// context would shadow the second.
+func conflicts(bindings map[context]map[string]binding) []string {
+	var errs []string
+	check := func(outer, inner context) {
+		for key, b := range bindings[inner] {
+			if prev, ok := bindings[outer][key]; ok {
+				errs = append(errs, fmt.Sprintf("key %q is bound to both %q (%s) and %q (%s)", key, prev.action(), outer, b.action(), inner))
+			}
+		}
+	}
+	for _, ctx := range []context{ctxList, ctxTree, ctxBoard, ctxColumn, ctxTask} {
+		check(ctxGlobal, ctx)
+	}
+	check(ctxBoard, ctxColumn)
+	check(ctxBoard, ctxTask)
+	return errs
+}
+
+// action returns the command and arguments of the binding.
+func (b binding) action() string {
+	return strings.Join(append([]string{b.command}, b.args...), " ")
+}
+
+// canonicalKey returns the key name used by [keyName] for a configured
+// key name. Modifiers and named keys are matched ignoring case, so
+// "ctrl-p" becomes "Ctrl+P" and "shift+enter" becomes "Shift+Enter".
+func canonicalKey(name string) (string, error) {
+	if utf8.RuneCountInString(name) == 1 {
+		return name, nil
+	}
+
+	var shift, alt, ctrl bool
+	base := name
+	for {
+		lower := strings.ToLower(base)
+		if strings.HasPrefix(lower, "shift+") {
+			shift, base = true, base[len("shift+"):]
+		} else if strings.HasPrefix(lower, "alt+") {
+			alt, base = true, base[len("alt+"):]
+		} else if strings.HasPrefix(lower, "ctrl+") || strings.HasPrefix(lower, "ctrl-") {
+			ctrl, base = true, base[len("ctrl+"):]
+		} else {
+			break
+		}
+	}
+
+	var mods []string
+	if shift {
+		mods = append(mods, "Shift")
+	}
+	if alt {
+		mods = append(mods, "Alt")
+	}
+	if ctrl {
+		mods = append(mods, "Ctrl")
+	}
+	key := func(base string) string {
+		return strings.Join(append(mods, base), "+")
+	}
+
+	if r, size := utf8.DecodeRuneInString(base); size == len(base) && size > 0 {
+		switch {
+		case ctrl && !shift && unicode.IsLetter(r) && r < unicode.MaxASCII:
+			return key(string(unicode.ToUpper(r))), nil
+		case alt && !shift && !ctrl:
+			return key(base), nil
+		}
+		return "", fmt.Errorf("invalid key %q", name)
+	}
+	if strings.EqualFold(base, "Space") && !shift && !alt && !ctrl {
+		return "Space", nil
+	}
+	for _, n := range tcell.KeyNames {
+		if ctrl && strings.EqualFold("Ctrl-"+base, n) {
+			return key(n[len("Ctrl-"):]), nil
+		}
+		if !strings.HasPrefix(n, "Ctrl-") && strings.EqualFold(base, n) {
+			return key(n), nil
+		}
+	}
+	return "", fmt.Errorf("invalid key %q", name)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ericstrs/bp/internal/config"
+)
+
+func Example_canonicalKey() {
+	for _, name := range []string{"a", "ctrl-p", "Ctrl+n", "shift+enter", "alt+x", "space", "pgdn", "Ctrl+Down", "Hyper+Q"} {
+		key, err := canonicalKey(name)
+		fmt.Println(key, err)
+	}
+
+	// Output:
+	// a <nil>
+	// Ctrl+P <nil>
+	// Ctrl+N <nil>
+	// Shift+Enter <nil>
+	// Alt+x <nil>
+	// Space <nil>
+	// PgDn <nil>
+	// Ctrl+Down <nil>
+	//  invalid key "Hyper+Q"
+}
+
+func Example_buildBindings() {
+	bindings, err := buildBindings(map[string]map[string]config.Keys{
+		"list": {
+			"move-down": {"J", "Ctrl+Down"},
+			"yank":      {},
+		},
+		"task": {"send 1": {"F1"}},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(bindings[ctxList]["Ctrl+Down"].action())
+	fmt.Println(bindings[ctxList]["J"].action())
+	_, ok := bindings[ctxList]["y"]
+	fmt.Println(ok)
+	fmt.Println(bindings[ctxTask]["F1"].action())
+	_, ok = bindings[ctxTask]["1"]
+	fmt.Println(ok)
+
+	// Output:
+	// move-down
+	// move-down
+	// false
+	// send 1
+	// false
+}
+
+func Example_buildBindings_conflicts() {
+	_, err := buildBindings(map[string]map[string]config.Keys{
+		"global": {"quit": {"x"}},
+		"board":  {"back": {"a"}},
+		"tree":   {"edit": {"a"}},
+		"bogus":  {"quit": {"q"}},
+		"task":   {"sort": {"s"}},
+	})
+	fmt.Println(err)
+
+	// Output:
+	// invalid key bindings:
+	// 	key "a" is bound to both "back" (board) and "add" (column)
+	// 	key "a" is bound to both "back" (board) and "add" (task)
+	// 	key "x" is bound to both "quit" (global) and "toggle-done" (list)
+	// 	tree: key "a" is bound to both "add" and "edit"
+	// 	unknown context "bogus"
+}
+
+// TestBuildBindingsDefaults checks that the default key bindings don't
+// conflict.
+func TestBuildBindingsDefaults(t *testing.T) {
+	if _, err := buildBindings(nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/rivo/tview"
+)
+
+// noLaneTitle is the header of the lane of tasks outside of the lanes
+// of a board.
+const noLaneTitle = "No lane"
+
+// A columnRow is a row of a board column table.
+type columnRow struct {
+	task int    // index of the task starting on the row, -1 if none
+	text string // description line, or title of a lane header
+	lane bool   // the row is a lane header
+}
+
+// boardLanes returns the lanes of the displayed board, followed by an
+// empty name for the tasks outside of them if there are any. It returns
+// nil if the board has no lanes.
+func (t *TUI) boardLanes() []string {
+	b, err := t.currentBoard()
+	if err != nil || len(b.GetLanes()) == 0 {
+		return nil
+	}
+	lanes := append([]string(nil), b.GetLanes()...)
+	for _, col := range t.boardColsData {
+		for _, task := range col.GetTasks() {
+			if b.LaneIndex(task.GetLane()) < 0 {
+				return append(lanes, "")
+			}
+		}
+	}
+	return lanes
+}
+
+// taskLane returns the lane a task is shown in on the displayed board,
+// which is empty for tasks outside of its lanes.
+func (t *TUI) taskLane(task tasks.BoardTask) string {
+	if b, err := t.currentBoard(); err == nil && b.LaneIndex(task.GetLane()) >= 0 {
+		return task.GetLane()
+	}
+	return ""
+}
+
+// laneTitle returns the header of the lane of the given name, filled
+// with a line to the given width.
+func laneTitle(name string, width int) string {
+	if name == "" {
+		name = noLaneTitle
+	}
+	title := "── " + name + " "
+	if n := width - tview.TaggedStringWidth(title); n > 0 {
+		title += strings.Repeat("─", n)
+	}
+	return title
+}
+
+// taskRows returns the rows of the visible tasks of the given column for
+// which in returns true, with descriptions wrapped to the given width and
+// checklists.
+func (t *TUI) taskRows(colIdx, width int, in func(task tasks.BoardTask) bool) []columnRow {
+	var rows []columnRow
+	for i, task := range t.boardColsData[colIdx].GetTasks() {
+		if !t.boardTaskVisible(colIdx, task) || !in(task) {
+			continue
+		}
+		rows = append(rows, columnRow{task: i})
+		if task.GetShowDesc() {
+			for _, line := range taskDetails(task.Task, width, t.theme.codeColor()) {
+				rows = append(rows, columnRow{task: -1, text: line})
+			}
+		}
+	}
+	return rows
+}
+
+// columnRows returns the rows of the given column of the displayed
+// board, with descriptions wrapped to the given width. On a board with
+// lanes, the tasks are grouped below the header of their lane, and each
+// lane is padded to the same height in every column so that the lanes
+// line up across the board.
+func (t *TUI) columnRows(colIdx, width int) []columnRow {
+	lanes := t.boardLanes()
+	if lanes == nil {
+		return t.taskRows(colIdx, width, func(tasks.BoardTask) bool { return true })
+	}
+	inLane := func(lane string) func(task tasks.BoardTask) bool {
+		return func(task tasks.BoardTask) bool { return t.taskLane(task) == lane }
+	}
+
+	heights := make([]int, len(lanes))
+	for i, col := range t.boardColsData {
+		if col.GetCollapsed() {
+			continue
+		}
+		for j, lane := range lanes {
+			if n := len(t.taskRows(i, width, inLane(lane))); n > heights[j] {
+				heights[j] = n
+			}
+		}
+	}
+
+	var rows []columnRow
+	for j, lane := range lanes {
+		rows = append(rows, columnRow{task: -1, text: laneTitle(lane, width), lane: true})
+		laneRows := t.taskRows(colIdx, width, inLane(lane))
+		for len(laneRows) < heights[j] {
+			laneRows = append(laneRows, columnRow{task: -1})
+		}
+		rows = append(rows, laneRows...)
+	}
+	return rows
+}
+
+// firstTaskRow returns the table row of the first task shown in the
+// focused column, or 0 if there is none.
+func (t *TUI) firstTaskRow() int {
+	for i, row := range t.columnRows(t.focusedCol, t.colWidth()) {
+		if row.task >= 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// nextLane selects the first task of the next lane of the focused
+// column with tasks, or of the previous lane if dir is negative.
+func (t *TUI) nextLane(dir int) error {
+	if t.boardLanes() == nil {
+		return errors.New("board has no lanes")
+	}
+	rows := t.columnRows(t.focusedCol, t.colWidth())
+	var headers []int
+	current := 0
+	for i, row := range rows {
+		if !row.lane {
+			continue
+		}
+		if i <= t.taskRow() {
+			current = len(headers)
+		}
+		headers = append(headers, i)
+	}
+	headers = append(headers, len(rows))
+	for lane := current + dir; lane >= 0 && lane < len(headers)-1; lane += dir {
+		for i := headers[lane] + 1; i < headers[lane+1]; i++ {
+			if rows[i].task >= 0 {
+				t.boardCols[t.focusedCol].Select(i, 0)
+				return nil
+			}
+		}
+	}
+	return errors.New("no more lanes with tasks")
+}
+
+// moveTaskLane moves the task on the given row of the focused column to
+// the next lane of the board, or to the previous lane if dir is
+// negative. Tasks move out of the last lane to the tasks outside of the
+// lanes.
+func (t *TUI) moveTaskLane(row, dir int) error {
+	b, err := t.currentBoard()
+	if err != nil {
+		return err
+	}
+	if len(b.GetLanes()) == 0 {
+		return errors.New("board has no lanes")
+	}
+	idx := t.calcTaskIdxBoard(row, t.colWidth())
+	task, err := t.boardColsData[t.focusedCol].GetTask(idx)
+	if err != nil {
+		return err
+	}
+	lanes := b.GetLanes()
+	i := b.LaneIndex(task.GetLane())
+	if i < 0 {
+		i = len(lanes)
+	}
+	i += dir
+	if i < 0 || i > len(lanes) {
+		return nil
+	}
+	lane := ""
+	if i < len(lanes) {
+		lane = lanes[i]
+	}
+	t.setTaskLane(idx, lane)
+	return nil
+}
+
+// setTaskLane moves the task at the given index of the focused column to
+// the named lane and keeps it selected.
+func (t *TUI) setTaskLane(idx int, lane string) {
+	task := &t.boardColsData[t.focusedCol].Tasks[idx]
+	task.SetLane(lane)
+	t.updateColumn(t.focusedCol)
+	t.boardCols[t.focusedCol].Select(t.calcRowBoard(idx, t.colWidth()), 0)
+}
+
+// runLane moves the current task to the named lane, adding the lane to
+// the board if it has none of that name. Without a name, the task moves
+// out of the lanes.
+func runLane(t *TUI, _ context, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: lane [name]")
+	}
+	b, err := t.currentBoard()
+	if err != nil {
+		return err
+	}
+	idx := t.boardTaskIdx()
+	if _, err := t.boardColsData[t.focusedCol].GetTask(idx); err != nil {
+		return err
+	}
+	lane := ""
+	if len(args) == 1 {
+		lane = args[0]
+		b.AddLane(lane)
+	}
+	t.setTaskLane(idx, lane)
+	return nil
+}
+
+// runLanes sets the lanes of the displayed board, in the given order.
+// Without names, the board shows no lanes, while tasks remember theirs.
+func runLanes(t *TUI, _ context, args []string) error {
+	b, err := t.currentBoard()
+	if err != nil {
+		return err
+	}
+	for i, name := range args {
+		for _, other := range args[:i] {
+			if other == name {
+				return fmt.Errorf("lane %q given twice", name)
+			}
+		}
+	}
+	b.SetLanes(args)
+	if t.isEmptyTable {
+		return nil
+	}
+	idx := -1
+	if ctx := t.focusContext(); ctx == ctxTask {
+		idx = t.boardTaskIdx()
+	}
+	for i := range t.boardCols {
+		t.updateColumn(i)
+	}
+	if idx >= 0 {
+		t.boardCols[t.focusedCol].Select(t.calcRowBoard(idx, t.colWidth()), 0)
+	}
+	return nil
+}
+
+// completeLane completes the lanes of the displayed board.
+func completeLane(t *TUI, arg string) []string {
+	b, err := t.currentBoard()
+	if err != nil {
+		return nil
+	}
+	return completeWords(b.GetLanes(), arg)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+func TestColumnRows(t *testing.T) {
+	tree := new(tasks.BoardTree)
+	board := tree.NewBoard("Project")
+	tree.AddRoot(board)
+	board.SetLanes([]string{"Bugs", "Features"})
+	add := func(col int, name, lane string) {
+		task := &tasks.BoardTask{Task: &tasks.Task{Name: name}, Lane: lane}
+		board.Columns[col].Add(task)
+	}
+	add(0, "crash", "Bugs")
+	add(0, "export", "Features")
+	add(0, "typo", "Bugs")
+	add(1, "import", "Features")
+	add(1, "refactor", "")
+	add(1, "search", "Features")
+
+	tui := &TUI{treeData: tree}
+	tui.InitTree()
+	tui.updateTree()
+	tui.tree.SetCurrentNode(tui.findBoardNode(board.GetID()))
+	tui.boardColsData = board.GetColumns()
+
+	rowTasks := func(col int) []int {
+		var got []int
+		for _, row := range tui.columnRows(col, 20) {
+			got = append(got, row.task)
+		}
+		return got
+	}
+	// Bugs: crash, typo; Features: export | import, search; No lane: refactor.
+	if got, want := rowTasks(0), []int{-1, 0, 2, -1, 1, -1, -1, -1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows of column 0 = %v, want %v", got, want)
+	}
+	if got, want := rowTasks(1), []int{-1, -1, -1, -1, 0, 2, -1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows of column 1 = %v, want %v", got, want)
+	}
+
+	board.SetLanes(nil)
+	if got, want := rowTasks(1), []int{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows of column 1 without lanes = %v, want %v", got, want)
+	}
+}
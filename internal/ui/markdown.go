@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/tview"
+)
+
+// mdStyle is a set of inline Markdown styles.
+type mdStyle uint8
+
+const (
+	mdBold mdStyle = 1 << iota
+	mdItalic
+	mdLink
+	mdCode
+)
+
+// An mdFrag is a piece of text of a single inline style.
+type mdFrag struct {
+	text  string
+	style mdStyle
+}
+
+var (
+	mdHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdBullet   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdNumber   = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdQuote    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdRule     = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdFence    = regexp.MustCompile("^\\s*(```|~~~)")
+	mdLinkText = regexp.MustCompile(`^\[([^\]]*)\]\(([^)\s]*)\)`)
+)
+
+// A markdown renders Markdown text as lines of tview styled text.
+type markdown struct {
+	width int
+	// code is the color of code, or empty to show code reversed.
+	code string
+}
+
+// renderMarkdown returns the lines of Markdown text styled for tview and
+// wrapped to the given width. It renders headings, bold and italic
+// text, lists, block quotes, rules, inline code, fenced code blocks and
+// links. Code is shown in the given color, or reversed if it is empty.
+// Line breaks within a paragraph are kept, as typed in the description.
+func renderMarkdown(text string, width int, code string) []string {
+	m := markdown{width: width, code: code}
+	lines := []string{}
+	blank := func() {
+		if len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+	}
+	var para []string
+	endPara := func() {
+		if len(para) > 0 {
+			// The lines are parsed together, so that styles may span them.
+			for _, frags := range splitLines(parseInline(strings.Join(para, "\n"))) {
+				lines = append(lines, m.wrap(frags, "", "")...)
+			}
+			para = nil
+		}
+	}
+
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		if mdFence.MatchString(line) {
+			endPara()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			// Code is shown as is, indented.
+			lines = append(lines, m.render([]mdFrag{{text: "  " + strings.TrimRight(line, " \t"), style: mdCode}}))
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			endPara()
+			blank()
+			continue
+		}
+		if mdRule.MatchString(line) {
+			endPara()
+			lines = append(lines, strings.Repeat("─", max(m.width, 1)))
+			continue
+		}
+		if s := mdHeading.FindStringSubmatch(line); s != nil {
+			endPara()
+			frags := parseInline(s[2])
+			for i := range frags {
+				frags[i].style |= mdBold
+			}
+			lines = append(lines, m.wrap(frags, "", "")...)
+			continue
+		}
+		if s := mdBullet.FindStringSubmatch(line); s != nil {
+			endPara()
+			indent := strings.Repeat("  ", len(s[1])/2)
+			lines = append(lines, m.wrap(parseInline(s[2]), indent+"• ", indent+"  ")...)
+			continue
+		}
+		if s := mdNumber.FindStringSubmatch(line); s != nil {
+			endPara()
+			indent := strings.Repeat("  ", len(s[1])/2)
+			marker := s[2] + ". "
+			lines = append(lines, m.wrap(parseInline(s[3]), indent+marker, indent+strings.Repeat(" ", len(marker)))...)
+			continue
+		}
+		if s := mdQuote.FindStringSubmatch(line); s != nil {
+			endPara()
+			lines = append(lines, m.wrap(parseInline(s[1]), "│ ", "│ ")...)
+			continue
+		}
+		para = append(para, strings.TrimSpace(line))
+	}
+	endPara()
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// parseInline splits a line of Markdown into pieces of text by their
+// inline style. Markers without a closing counterpart are kept as text.
+func parseInline(s string) []mdFrag {
+	var frags []mdFrag
+	var style mdStyle
+	var text strings.Builder
+	emit := func() {
+		if text.Len() > 0 {
+			frags = append(frags, mdFrag{text: text.String(), style: style})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1:
+			text.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				emit()
+				frags = append(frags, mdFrag{text: rest[1 : end+1], style: style | mdCode})
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[':
+			if l := mdLinkText.FindStringSubmatch(rest); l != nil {
+				emit()
+				frags = append(frags, mdFrag{text: l[1], style: style | mdLink})
+				if l[2] != "" && l[2] != l[1] {
+					frags = append(frags, mdFrag{text: " (" + l[2] + ")", style: style})
+				}
+				i += len(l[0])
+				continue
+			}
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if style&mdBold != 0 || strings.Contains(rest[2:], rest[:2]) {
+				emit()
+				style ^= mdBold
+				i += 2
+				continue
+			}
+		case rest[0] == '*' || rest[0] == '_':
+			if toggleItalic(s, i, style&mdItalic != 0) {
+				emit()
+				style ^= mdItalic
+				i++
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(rest)
+		text.WriteString(rest[:size])
+		i += size
+	}
+	emit()
+	return frags
+}
+
+// toggleItalic reports whether the * or _ at index i of s opens or, if
+// closing is true, closes italic text. Markers within words, such as in
+// snake_case, and lone markers are kept as text.
+func toggleItalic(s string, i int, closing bool) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i+1:])
+	if closing {
+		return i > 0 && !unicode.IsSpace(before) && (i+1 == len(s) || !isWordRune(after))
+	}
+	if i+1 == len(s) || unicode.IsSpace(after) || (i > 0 && isWordRune(before)) {
+		return false
+	}
+	return strings.IndexByte(s[i+1:], s[i]) >= 0
+}
+
+// isWordRune reports whether r is part of a word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wrap returns the styled lines of the pieces of text wrapped at word
+// boundaries. The first line starts with first and the others with
+// rest, such as a list bullet and its indentation.
+func (m markdown) wrap(frags []mdFrag, first, rest string) []string {
+	var lines []string
+	prefix := first
+	var line []mdFrag
+	lineWidth := 0
+	flush := func() {
+		lines = append(lines, tview.Escape(prefix)+m.render(line))
+		prefix, line, lineWidth = rest, nil, 0
+	}
+	for _, word := range mdWords(frags) {
+		width := 0
+		for _, f := range word {
+			width += tview.TaggedStringWidth(tview.Escape(f.text))
+		}
+		if len(line) > 0 && tview.TaggedStringWidth(tview.Escape(prefix))+lineWidth+1+width > m.width {
+			flush()
+		}
+		if len(line) > 0 {
+			// A space between words of the same style has their style,
+			// so that links are underlined as a whole.
+			space := mdFrag{text: " "}
+			if last := line[len(line)-1].style; last == word[0].style {
+				space.style = last
+			}
+			line = append(line, space)
+			lineWidth++
+		}
+		line = append(line, word...)
+		lineWidth += width
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
+
+// splitLines splits pieces of text at line breaks into the pieces of
+// each line.
+func splitLines(frags []mdFrag) [][]mdFrag {
+	lines := [][]mdFrag{nil}
+	for _, f := range frags {
+		for i, text := range strings.Split(f.text, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+			}
+			if text != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], mdFrag{text: text, style: f.style})
+			}
+		}
+	}
+	return lines
+}
+
+// mdWords splits pieces of text into words, each of one or more pieces.
+func mdWords(frags []mdFrag) [][]mdFrag {
+	var words [][]mdFrag
+	var word []mdFrag
+	for _, f := range frags {
+		start := 0
+		for i, r := range f.text {
+			if !unicode.IsSpace(r) {
+				continue
+			}
+			if i > start {
+				word = append(word, mdFrag{text: f.text[start:i], style: f.style})
+			}
+			if len(word) > 0 {
+				words = append(words, word)
+				word = nil
+			}
+			start = i + utf8.RuneLen(r)
+		}
+		if start < len(f.text) {
+			word = append(word, mdFrag{text: f.text[start:], style: f.style})
+		}
+	}
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+	return words
+}
+
+// render returns the pieces of text as tview styled text.
+func (m markdown) render(frags []mdFrag) string {
+	var b strings.Builder
+	var style mdStyle
+	for _, f := range frags {
+		if f.style != style {
+			b.WriteString(m.tag(f.style))
+			style = f.style
+		}
+		b.WriteString(tview.Escape(f.text))
+	}
+	if style != 0 {
+		b.WriteString(m.tag(0))
+	}
+	return b.String()
+}
+
+// tag returns the tview style tag of an inline style. It resets the
+// style of the text before, so that styles aren't combined by accident.
+func (m markdown) tag(style mdStyle) string {
+	tag := "[-::-]"
+	if style == 0 {
+		return tag
+	}
+	fg, attrs := "", ""
+	if style&mdBold != 0 {
+		attrs += "b"
+	}
+	if style&mdItalic != 0 {
+		attrs += "i"
+	}
+	if style&mdLink != 0 {
+		attrs += "u"
+	}
+	if style&mdCode != 0 {
+		if m.code == "" {
+			attrs += "r"
+		} else {
+			fg = m.code
+		}
+	}
+	return tag + "[" + fg + "::" + attrs + "]"
+}
+
+// max returns the larger of a and b.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,67 @@
+package ui
+
+import "fmt"
+
+func Example_renderMarkdown() {
+	text := "# Release *v2*\n\n" +
+		"Run `go test` before\ntagging, see [the docs](https://example.com).\n\n" +
+		"- keep **snake_case** names\n" +
+		"  - nested item\n" +
+		"1. tag\n\n" +
+		"```\nfmt.Println(\"[x]\")\n```\n" +
+		"---"
+	for _, line := range renderMarkdown(text, 24, "") {
+		fmt.Printf("%q\n", line)
+	}
+
+	// Output:
+	// "[-::-][::b]Release[-::-] [-::-][::bi]v2[-::-]"
+	// ""
+	// "Run [-::-][::r]go test[-::-] before"
+	// "tagging, see [-::-][::u]the docs[-::-]"
+	// "(https://example.com)."
+	// ""
+	// "• keep [-::-][::b]snake_case[-::-] names"
+	// "  • nested item"
+	// "1. tag"
+	// ""
+	// "[-::-][::r]  fmt.Println(\"[x[]\")[-::-]"
+	// "────────────────────────"
+}
+
+func Example_renderMarkdown_lineBreaks() {
+	text := "Steps:\n**write\nthe notes**\ntag"
+	for _, line := range renderMarkdown(text, 24, "") {
+		fmt.Printf("%q\n", line)
+	}
+
+	// Output:
+	// "Steps:"
+	// "[-::-][::b]write[-::-]"
+	// "[-::-][::b]the notes[-::-]"
+	// "tag"
+}
+
+func Example_renderMarkdown_paragraphs() {
+	text := "Write the release notes.\n\nThen tag the version."
+	for _, line := range renderMarkdown(text, 12, "") {
+		fmt.Printf("%q\n", line)
+	}
+
+	// Output:
+	// "Write the"
+	// "release"
+	// "notes."
+	// ""
+	// "Then tag the"
+	// "version."
+}
+
+func Example_parseInline() {
+	for _, f := range parseInline("a * b, 2*3 and *lone") {
+		fmt.Printf("%q %d\n", f.text, f.style)
+	}
+
+	// Output:
+	// "a * b, 2*3 and *lone" 0
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// initMouse enables the mouse. Clicks select todo list tasks, tree
+// nodes and board tasks, the wheel scrolls, a double-click enters the
+// sub-board of a task and dragging a task onto another column moves it
+// there.
+func (t *TUI) initMouse() {
+	t.app.EnableMouse(true)
+	t.app.SetMouseCapture(t.mouseCapture)
+}
+
+// mouseCapture handles mouse actions before they reach the primitive
+// under the mouse. It keeps the focused panel and board column in sync
+// with clicks and implements dragging tasks between columns.
+func (t *TUI) mouseCapture(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	if action != tview.MouseMove {
+		t.noteInput()
+	}
+	// Overlays, such as the help, handle the mouse themselves.
+	if name, _ := t.pages.GetFrontPage(); name != "main" {
+		return event, action
+	}
+	x, y := event.Position()
+	switch action {
+	case tview.MouseLeftDown:
+		t.dragging = false
+		switch {
+		case t.panelShown(t.leftPanel) && t.leftPanel.InRect(x, y):
+			t.focusPanel(t.leftPanel)
+		case t.panelShown(t.rightPanel) && t.rightPanel.InRect(x, y):
+			t.focusPanel(t.rightPanel)
+			if t.boardShown() && t.breadcrumb.InRect(x, y) {
+				// Segments are clicked rather than focused.
+				return nil, action
+			}
+			if col, row, ok := t.taskAt(x, y); ok {
+				t.focusBoardRow(col, row)
+				t.dragging = true
+				t.dragCol, t.dragRow = col, row
+				return nil, action
+			}
+			if col := t.columnAt(x, y); col >= 0 {
+				t.focusColumn(col)
+				return nil, action
+			}
+		}
+	case tview.MouseLeftUp:
+		if !t.dragging {
+			break
+		}
+		t.dragging = false
+		if col := t.columnAt(x, y); col >= 0 && col != t.dragCol && t.focusedCol == t.dragCol {
+			t.moveBoardTask(t.dragRow, col, true)
+			return nil, action
+		}
+	case tview.MouseLeftDoubleClick:
+		if col, row, ok := t.taskAt(x, y); ok && col == t.focusedCol {
+			t.enterSubBoard(row)
+			return nil, action
+		}
+	}
+	return event, action
+}
+
+// panelShown reports whether a panel is shown, which it isn't while the
+// other panel is zoomed in.
+func (t *TUI) panelShown(panel *tview.Grid) bool {
+	return !t.zoomedIn || t.focusedPanel == panel
+}
+
+// focusPanel focuses the given panel if it isn't focused already.
+func (t *TUI) focusPanel(panel *tview.Grid) {
+	if t.focusedPanel != panel {
+		t.switchPanel()
+	}
+}
+
+// columnAt returns the index of the board column at the given screen
+// position, or -1 if there is none.
+func (t *TUI) columnAt(x, y int) int {
+	if !t.boardShown() {
+		return -1
+	}
+	// Columns that aren't shown keep the position they were last drawn at.
+	first, n := t.shownCols()
+	for i := first; i < first+n; i++ {
+		if t.boardCols[i].InRect(x, y) {
+			return i
+		}
+	}
+	return -1
+}
+
+// taskAt returns the board column and table row of the task at the
+// given screen position. It reports false if there is no task there,
+// such as on a column border or a description line.
+func (t *TUI) taskAt(x, y int) (col, row int, ok bool) {
+	col = t.columnAt(x, y)
+	if col < 0 {
+		return 0, 0, false
+	}
+	table := t.boardCols[col]
+	_, top, _, height := table.GetInnerRect()
+	if y < top || y >= top+height {
+		return 0, 0, false
+	}
+	offset, _ := table.GetOffset()
+	row = y - top + offset
+	if row >= table.GetRowCount() || table.GetCell(row, 0).NotSelectable {
+		return 0, 0, false
+	}
+	// Without visible tasks, the only row tells so.
+	for _, task := range t.boardColsData[col].GetTasks() {
+		if t.boardTaskVisible(col, task) {
+			return col, row, true
+		}
+	}
+	return 0, 0, false
+}
+
+// focusBoardRow focuses the given column of the displayed board and
+// selects the task on the given table row.
+func (t *TUI) focusBoardRow(col, row int) {
+	t.boardCols[t.focusedCol].SetSelectable(false, false)
+	t.focusedCol = col
+	table := t.boardCols[col]
+	table.SetSelectable(true, false)
+	table.Select(row, 0)
+	t.app.SetFocus(table)
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// pickerItem is a single selectable entry of a picker.
+type pickerItem struct {
+	Text string      // text that is displayed and matched against
+	Ref  interface{} // value handed back on selection
+}
+
+// showPicker displays a modal with an input field and a list of items
+// that are fuzzy filtered as the user types. Enter selects the
+// highlighted item and hands it to the done function, while Escape
+// closes the picker without making a selection.
+func (t *TUI) showPicker(title string, items []pickerItem, done func(item pickerItem)) {
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = item.Text
+	}
+	query := func(pattern string) []pickerItem {
+		var matches []pickerItem
+		for _, idx := range fuzzyFilter(pattern, texts) {
+			matches = append(matches, items[idx])
+		}
+		return matches
+	}
+	t.showQueryPicker(title, query, done)
+}
+
+// showQueryPicker displays a picker whose items are produced by the
+// given query function each time the input changes.
+func (t *TUI) showQueryPicker(title string, query func(pattern string) []pickerItem, done func(item pickerItem)) {
+	prevFocus := t.app.GetFocus()
+
+	var matches []pickerItem
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true).
+		SetSelectedStyle(t.theme.selectedStyle())
+
+	filter := func(pattern string) {
+		list.Clear()
+		matches = query(pattern)
+		for _, item := range matches {
+			list.AddItem(tview.Escape(item.Text), "", 0, nil)
+		}
+	}
+
+	closePicker := func() {
+		t.pages.RemovePage("picker")
+		t.app.SetFocus(prevFocus)
+	}
+
+	input := tview.NewInputField().
+		SetLabel("> ").
+		SetFieldWidth(0).
+		SetChangedFunc(filter)
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyCtrlP, tcell.KeyBacktab:
+			if list.GetCurrentItem() > 0 {
+				list.SetCurrentItem(list.GetCurrentItem() - 1)
+			}
+			return nil
+		case tcell.KeyDown, tcell.KeyCtrlN, tcell.KeyTab:
+			if list.GetCurrentItem() < list.GetItemCount()-1 {
+				list.SetCurrentItem(list.GetCurrentItem() + 1)
+			}
+			return nil
+		case tcell.KeyEnter:
+			if len(matches) == 0 {
+				return nil
+			}
+			item := matches[list.GetCurrentItem()]
+			closePicker()
+			done(item)
+			return nil
+		case tcell.KeyEscape:
+			closePicker()
+			return nil
+		}
+		return event
+	})
+	filter("")
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	layout.SetBorder(true)
+	layout.SetTitle(title)
+
+	modal := tview.NewGrid().
+		SetColumns(0, 60, 0).
+		SetRows(0, 20, 0).
+		AddItem(layout, 1, 1, 1, 1, 0, 0, true)
+
+	t.pages.AddPage("picker", modal, true, true)
+	t.app.SetFocus(input)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/rivo/tview"
+)
+
+// progressText formats the progress of a sub-board, such as "[7/12]".
+func progressText(done, total int) string {
+	return fmt.Sprintf("[%d/%d]", done, total)
+}
+
+// taskProgress returns the progress of the sub-board of a task, or an
+// empty string if the task has none.
+func (t *TUI) taskProgress(task tasks.BoardTask) string {
+	if !task.GetHasChild() {
+		return ""
+	}
+	child, err := t.treeData.GetBoard(task.GetChildID())
+	if err != nil {
+		return ""
+	}
+	return progressText(t.treeData.Progress(child))
+}
+
+// boardNodeText returns the text of the tree node of a board after the
+// given arrow: the board title, followed by the progress of the board if
+// it is the sub-board of a task.
+func (t *TUI) boardNodeText(arrow string, b *tasks.Board) string {
+	text := arrow + b.GetTitle()
+	if _, err := t.treeData.GetParentBoard(b.GetID()); err == nil {
+		text += " " + tview.Escape(progressText(t.treeData.Progress(b)))
+	}
+	return text
+}
+
+// refreshProgress updates the progress shown by the tree nodes of the
+// given boards and of the boards above them, after their tasks changed.
+func (t *TUI) refreshProgress(boards ...*tasks.Board) {
+	if t.tree == nil {
+		return
+	}
+	ids := make(map[int]bool)
+	for _, b := range boards {
+		path, err := t.treeData.Path(b.GetID())
+		if err != nil {
+			continue
+		}
+		for _, p := range path {
+			ids[p.GetID()] = true
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	t.tree.GetRoot().Walk(func(node, _ *tview.TreeNode) bool {
+		ref, ok := node.GetReference().(NodeRef)
+		if !ok || !ids[ref.ID] {
+			return true
+		}
+		b, err := t.treeData.GetBoard(ref.ID)
+		if err != nil {
+			return true
+		}
+		arrow := "▸ "
+		if node.IsExpanded() {
+			arrow = "▾ "
+		}
+		text := t.boardNodeText(arrow, b)
+		if node == t.markedNode {
+			text = treeMarker + text
+		}
+		node.SetText(text)
+		return true
+	})
+}
+
+// completeParents moves the tasks whose sub-boards are all done to the
+// done column of their board, starting from the given boards, if
+// enabled. The tree view is rebuilt if any task moved.
+func (t *TUI) completeParents(boards ...*tasks.Board) {
+	if !t.autoComplete {
+		return
+	}
+	var moved []string
+	for _, b := range boards {
+		moved = append(moved, t.treeData.CompleteParents(b)...)
+	}
+	if len(moved) == 0 {
+		return
+	}
+	for i, name := range moved {
+		moved[i] = fmt.Sprintf("%q", name)
+	}
+	t.infof("Completed %s", strings.Join(moved, ", "))
+	if b, err := t.currentBoard(); err == nil {
+		t.reloadTree(b)
+	}
+}
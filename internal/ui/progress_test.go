@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+func Example_progressText() {
+	fmt.Println(progressText(7, 12))
+
+	// Output:
+	// [7/12]
+}
+
+func ExampleTUI_boardNodeText() {
+	tree := new(tasks.BoardTree)
+	project := tree.NewBoard("Project")
+	tree.AddRoot(project)
+	api := tree.NewBoard("API")
+	tree.AddChildBoard(api)
+	project.AddChild(api.ID)
+	api.Columns[0].Tasks = []tasks.BoardTask{{Task: &tasks.Task{Name: "auth", Done: true}}}
+
+	t := &TUI{treeData: tree}
+	fmt.Println(t.boardNodeText("▾ ", project))
+	fmt.Println(t.boardNodeText("▸ ", api))
+
+	// Output:
+	// ▾ Project
+	// ▸ API [1/1]
+}
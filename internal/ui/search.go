@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/rivo/tview"
+)
+
+// showSearch opens a picker that searches the names and descriptions
+// of every task in the todo list and the board tree, and jumps to the
+// selected task.
+func (t *TUI) showSearch() {
+	query := func(pattern string) []pickerItem {
+		var items []pickerItem
+		for _, r := range tasks.Search(t.taskData, t.treeData, pattern) {
+			items = append(items, pickerItem{
+				Text: r.Task.GetName() + "  —  " + r.Location(t.taskData, t.treeData),
+				Ref:  r,
+			})
+		}
+		t.searchQuery = pattern
+		return items
+	}
+	t.showQueryPicker("Search", query, func(item pickerItem) {
+		r := item.Ref.(tasks.SearchResult)
+		t.jumpToResult(r)
+	})
+}
+
+// nextMatch jumps to the next search match, or the previous one if dir
+// is negative, wrapping around at either end. The search is re-run so
+// that changes made since the last jump are taken into account.
+func (t *TUI) nextMatch(dir int) {
+	if t.searchQuery == "" {
+		return
+	}
+	results := tasks.Search(t.taskData, t.treeData, t.searchQuery)
+	if len(results) == 0 {
+		return
+	}
+
+	cur := -1
+	for i, r := range results {
+		if r.Task == t.searchTask {
+			cur = i
+			break
+		}
+	}
+	next := cur + dir
+	if cur == -1 && dir < 0 {
+		next = len(results) - 1
+	}
+	next = (next + len(results)) % len(results)
+	t.jumpToResult(results[next])
+}
+
+// jumpToResult focuses the task of a search result, opening the board
+// that holds it if needed.
+func (t *TUI) jumpToResult(r tasks.SearchResult) {
+	t.searchTask = r.Task
+	if r.Board == nil {
+		if t.focusedPanel != t.leftPanel {
+			t.switchPanel()
+		}
+		t.list.Select(t.calcRow(r.Index, t.leftPanelWidth), 0)
+		return
+	}
+
+	if err := t.openBoard(r.Board); err != nil {
+		t.errorf("Failed to jump to search result: %v", err)
+		return
+	}
+	t.focusBoardTask(r.Column, r.Index)
+}
+
+// revealNode expands every ancestor of the given tree view node so
+// that the node is visible.
+func (t *TUI) revealNode(target *tview.TreeNode) {
+	parents := make(map[*tview.TreeNode]*tview.TreeNode)
+	t.tree.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+		parents[node] = parent
+		return true
+	})
+	for n := parents[target]; n != nil; n = parents[n] {
+		if !n.IsExpanded() {
+			t.setExpanded(n, true)
+		}
+	}
+}
+
+// focusBoardTask selects the task at the given index of the given
+// column of the displayed board, expanding the column if collapsed.
+func (t *TUI) focusBoardTask(colIdx, taskIdx int) {
+	if colIdx < 0 || colIdx >= len(t.boardCols) {
+		return
+	}
+	if col := &t.boardColsData[colIdx]; col.GetCollapsed() {
+		col.SetCollapsed(false)
+		t.updateColumn(colIdx)
+	}
+	t.boardCols[t.focusedCol].SetSelectable(false, false)
+	t.focusedCol = colIdx
+	table := t.boardCols[colIdx]
+	table.SetSelectable(true, false)
+	lineWidth := t.colWidth()
+	table.Select(t.calcRowBoard(taskIdx, lineWidth), 0)
+	t.app.SetFocus(table)
+}
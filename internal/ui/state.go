@@ -0,0 +1,96 @@
+package ui
+
+import "github.com/rivo/tview"
+
+// Names of the panels in the saved state.
+const (
+	panelList  = "list"
+	panelBoard = "board"
+)
+
+// State is the state of the user interface that is saved between
+// sessions, separately from the todo list and boards.
+type State struct {
+	Board     int      `yaml:"board,omitempty"`     // id of the displayed board, 0 if the tree view is shown
+	Column    int      `yaml:"column,omitempty"`    // focused column of the displayed board
+	Task      int      `yaml:"task,omitempty"`      // index of the selected task of the focused column
+	TreeNode  string   `yaml:"tree_node,omitempty"` // key of the current tree view node
+	ListTask  int      `yaml:"list_task,omitempty"` // index of the selected todo list task
+	Panel     string   `yaml:"panel,omitempty"`     // focused panel, list or board
+	Zoomed    bool     `yaml:"zoomed,omitempty"`    // focused panel is zoomed in
+	Collapsed []string `yaml:"collapsed,omitempty"` // keys of the collapsed tree view nodes
+}
+
+// SetState sets the state restored when the user interface starts.
+func (t *TUI) SetState(s *State) { t.state = s }
+
+// State returns the current state of the user interface.
+func (t *TUI) State() *State {
+	s := &State{
+		ListTask:  t.listIdx(),
+		Panel:     panelList,
+		Zoomed:    t.zoomedIn,
+		Collapsed: t.treeData.Collapsed,
+	}
+	if t.focusedPanel == t.rightPanel {
+		s.Panel = panelBoard
+	}
+	node := t.tree.GetCurrentNode()
+	s.TreeNode = t.nodeKey(node)
+	if b := t.nodeBoard(node); b != nil && t.boardShown() {
+		s.Board = b.GetID()
+		if len(t.boardCols) > 0 {
+			s.Column = t.focusedCol
+			s.Task = t.boardTaskIdx()
+		}
+	}
+	return s
+}
+
+// restoreState restores the state set with SetState. Boards, columns
+// and tasks that no longer exist are skipped.
+func (t *TUI) restoreState() {
+	s := t.state
+	if s == nil {
+		return
+	}
+	if s.ListTask > 0 && s.ListTask < len(t.taskData.GetTasks()) {
+		t.list.Select(t.calcRow(s.ListTask, t.leftPanelWidth), 0)
+	}
+
+	if node := t.findNodeByKey(s.TreeNode); node != nil {
+		t.revealNode(node)
+		t.tree.SetCurrentNode(node)
+	}
+	if b, err := t.treeData.GetBoard(s.Board); s.Board != 0 && err == nil {
+		if err := t.openBoard(b); err != nil {
+			t.errorf("Failed to restore board: %v", err)
+		} else if s.Column < len(t.boardCols) {
+			t.focusBoardTask(s.Column, s.Task)
+		}
+	}
+
+	switch {
+	case s.Panel == panelBoard && t.focusedPanel != t.rightPanel,
+		s.Panel == panelList && t.focusedPanel != t.leftPanel:
+		t.switchPanel()
+	}
+	if s.Zoomed {
+		t.toggleZoom()
+	}
+}
+
+// findNodeByKey returns the tree view node with the given key, or nil.
+func (t *TUI) findNodeByKey(key string) *tview.TreeNode {
+	if key == "" {
+		return nil
+	}
+	var found *tview.TreeNode
+	t.tree.GetRoot().Walk(func(node, _ *tview.TreeNode) bool {
+		if found == nil && t.nodeKey(node) == key {
+			found = node
+		}
+		return found == nil
+	})
+	return found
+}
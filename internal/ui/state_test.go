@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+func TestFindNodeByKey(t *testing.T) {
+	tree := new(tasks.BoardTree)
+	board := tree.NewBoard("Project")
+	tree.AddRoot(board)
+	tui := &TUI{treeData: tree}
+	tui.InitTree()
+	tui.updateTree()
+
+	for _, key := range []string{"board:1", "board:1/column:2"} {
+		node := tui.findNodeByKey(key)
+		if node == nil {
+			t.Errorf("findNodeByKey(%q) = nil", key)
+			continue
+		}
+		if got := tui.nodeKey(node); got != key {
+			t.Errorf("findNodeByKey(%q) found node with key %q", key, got)
+		}
+	}
+	if node := tui.findNodeByKey("board:2"); node != nil {
+		t.Errorf("findNodeByKey(%q) = %q, want nil", "board:2", node.GetText())
+	}
+}
+
+func TestShiftCollapsedCols(t *testing.T) {
+	tests := []struct {
+		idx, delta int
+		want       []string
+	}{
+		{1, 1, []string{"board:1", "board:1/column:0", "board:1/column:2", "board:1/column:3", "board:2/column:1"}},
+		{1, -1, []string{"board:1", "board:1/column:0", "board:1/column:1", "board:2/column:1"}},
+		{3, -1, []string{"board:1", "board:1/column:0", "board:1/column:1", "board:1/column:2", "board:2/column:1"}},
+	}
+	for _, tt := range tests {
+		tree := new(tasks.BoardTree)
+		board := tree.NewBoard("Project")
+		tree.AddRoot(board)
+		tree.Collapsed = []string{"board:1", "board:1/column:0", "board:1/column:1", "board:1/column:2", "board:2/column:1"}
+		tui := &TUI{treeData: tree}
+		tui.shiftCollapsedCols(board, tt.idx, tt.delta)
+		if got := strings.Join(tree.Collapsed, " "); got != strings.Join(tt.want, " ") {
+			t.Errorf("shiftCollapsedCols(%d, %d) = %q, want %q", tt.idx, tt.delta, tree.Collapsed, tt.want)
+		}
+	}
+}
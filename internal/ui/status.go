@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+// statusTimeout is how long a status message stays visible.
+const statusTimeout = 5 * time.Second
+
+// A statusLevel is the severity of a status message.
+type statusLevel int
+
+const (
+	statusInfo statusLevel = iota
+	statusWarn
+	statusError
+)
+
+// initStatusBar initializes the status bar. Its left side shows
+// transient messages, its right side the location, task counts and
+// whether there are unsaved changes.
+func (t *TUI) initStatusBar() {
+	t.statusMsg = tview.NewTextView().
+		SetDynamicColors(false).
+		SetWrap(false)
+	t.statusInfo = tview.NewTextView().
+		SetTextAlign(tview.AlignRight).
+		SetWrap(false)
+	t.statusBar = tview.NewFlex().
+		AddItem(t.statusMsg, 0, 1, false).
+		AddItem(t.statusInfo, 0, 1, false)
+}
+
+// infof shows an informational message in the status bar.
+func (t *TUI) infof(format string, a ...interface{}) {
+	t.setStatus(statusInfo, fmt.Sprintf(format, a...))
+}
+
+// warnf shows a warning in the status bar and logs it.
+func (t *TUI) warnf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	log.Println("Warning:", msg)
+	t.setStatus(statusWarn, msg)
+}
+
+// errorf shows an error in the status bar and logs it.
+func (t *TUI) errorf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	log.Println(msg)
+	t.setStatus(statusError, msg)
+}
+
+// setStatus shows a message in the status bar until it times out or is
+// replaced by another message.
+func (t *TUI) setStatus(level statusLevel, msg string) {
+	style := tcell.StyleDefault.Foreground(t.theme.Text).Background(t.theme.Background)
+	switch level {
+	case statusWarn:
+		style = style.Foreground(t.theme.Warning)
+	case statusError:
+		style = style.Foreground(t.theme.Error)
+	}
+	if t.theme.Monochrome && level != statusInfo {
+		style = tcell.StyleDefault.Bold(true)
+	}
+	t.statusMsg.SetTextStyle(style).SetText(msg)
+
+	t.statusGen++
+	gen := t.statusGen
+	time.AfterFunc(statusTimeout, func() {
+		t.app.QueueUpdateDraw(func() {
+			// Keep newer messages.
+			if gen == t.statusGen {
+				t.statusMsg.SetText("")
+			}
+		})
+	})
+}
+
+// updateStatus updates the right side of the status bar for the focused
+// context.
+func (t *TUI) updateStatus() {
+	var parts []string
+	switch t.focusContext() {
+	case ctxList:
+		done, total := 0, len(t.taskData.Tasks)
+		for _, task := range t.taskData.Tasks {
+			if task.GetIsDone() {
+				done++
+			}
+		}
+		parts = append(parts, t.taskData.GetTitle(), fmt.Sprintf("%d/%d done", done, total))
+	case ctxTree:
+		if b := t.nodeBoard(t.tree.GetCurrentNode()); b != nil {
+			parts = append(parts, t.boardPath(b))
+		}
+		parts = append(parts, plural(len(t.treeData.Boards()), "board"))
+	default:
+		if b := t.nodeBoard(t.tree.GetCurrentNode()); b != nil {
+			parts = append(parts, t.boardPath(b), boardCounts(b))
+		}
+	}
+	if t.isDirty() {
+		parts = append(parts, "modified")
+	} else {
+		parts = append(parts, "saved")
+	}
+	t.statusInfo.SetText(strings.Join(parts, " │ "))
+}
+
+// nodeBoard returns the board referenced by a tree node, or nil. Unlike
+// getBoardRef, it doesn't report missing boards, since it is called on
+// every draw.
+func (t *TUI) nodeBoard(n *tview.TreeNode) *tasks.Board {
+	if n == nil {
+		return nil
+	}
+	nr, ok := n.GetReference().(NodeRef)
+	if !ok || nr.Type != "Board" {
+		return nil
+	}
+	b, err := t.treeData.GetBoard(nr.ID)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// boardCounts returns the number of done and total tasks of a board.
+func boardCounts(b *tasks.Board) string {
+	done, total := 0, 0
+	for _, col := range b.GetColumns() {
+		for _, task := range col.GetTasks() {
+			total++
+			if task.GetIsDone() {
+				done++
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%d done", done, total)
+}
+
+// snapshot returns the serialized todo list, boards and saved queries,
+// as they would be saved.
+func (t *TUI) snapshot() []byte {
+	data, err := yaml.Marshal([]interface{}{t.taskData, t.treeData, t.queries})
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// markSaved records the current state as saved.
+func (t *TUI) markSaved() {
+	t.savedState = t.snapshot()
+	t.dirty, t.checkDirty = false, false
+}
+
+// noteInput records that the data may have changed, such as after a key
+// press or a click, to compare it with the saved state on the next draw.
+func (t *TUI) noteInput() { t.checkDirty = true }
+
+// isDirty reports whether there are changes since the last save. The data
+// is only serialized after input that may have changed it, and not while
+// an overlay, such as a form being typed into, is open.
+func (t *TUI) isDirty() bool {
+	if !t.checkDirty {
+		return t.dirty
+	}
+	if name, _ := t.pages.GetFrontPage(); name == "main" {
+		t.dirty = string(t.snapshot()) != string(t.savedState)
+		t.checkDirty = false
+	}
+	return t.dirty
+}
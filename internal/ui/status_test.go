@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/rivo/tview"
+)
+
+func Example_boardCounts() {
+	tree := new(tasks.BoardTree)
+	board := tree.NewBoard("Project")
+	for i, name := range []string{"design", "build"} {
+		board.Columns[0].Add(&tasks.BoardTask{Task: &tasks.Task{Name: name, Done: i == 0}})
+	}
+	fmt.Println(boardCounts(board))
+
+	// Output:
+	// 1/2 done
+}
+
+func TestIsDirty(t *testing.T) {
+	tui := &TUI{
+		taskData: new(tasks.TodoList),
+		treeData: new(tasks.BoardTree),
+		queries:  new(tasks.SavedQueries),
+		pages:    tview.NewPages().AddPage("main", tview.NewBox(), true, true),
+	}
+	tui.markSaved()
+	if tui.isDirty() {
+		t.Fatal("isDirty() = true right after markSaved")
+	}
+	tui.treeData.NewBoard("Project")
+	if tui.isDirty() {
+		t.Fatal("isDirty() = true before any input was noted")
+	}
+	tui.noteInput()
+	tui.pages.AddPage("modal", tview.NewBox(), true, true)
+	if tui.isDirty() {
+		t.Fatal("isDirty() = true while a modal is open")
+	}
+	tui.pages.RemovePage("modal")
+	if !tui.isDirty() {
+		t.Fatal("isDirty() = false after adding a board")
+	}
+	tui.markSaved()
+	if tui.isDirty() {
+		t.Fatal("isDirty() = true after saving again")
+	}
+}
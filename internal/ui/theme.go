@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// A Theme holds the colors of the user interface.
+type Theme struct {
+	Background   tcell.Color
+	Text         tcell.Color
+	Border       tcell.Color
+	Title        tcell.Color
+	Selected     tcell.Color // background of the selected row
+	SelectedText tcell.Color
+	Done         tcell.Color // done tasks
+	Description  tcell.Color // task descriptions
+	ColumnHeader tcell.Color // board column titles
+	TreeBoard    tcell.Color // board nodes of the tree view
+	TreeColumn   tcell.Color // column nodes of the tree view
+	TreeTask     tcell.Color // task nodes of the tree view
+	Field        tcell.Color // background of form fields and buttons
+	FieldText    tcell.Color
+	Warning      tcell.Color // status bar warnings
+	Error        tcell.Color // status bar errors
+
+	// Monochrome themes use text attributes instead of colors, and mark
+	// the current tree node with a prefix, since the tree view highlights
+	// it by its colors.
+	Monochrome bool
+}
+
+// themes holds the built-in themes by name.
+var themes = map[string]Theme{
+	"default": {
+		Background:   tcell.ColorBlack,
+		Text:         tcell.ColorWhite,
+		Border:       tcell.ColorWhite,
+		Title:        tcell.ColorWhite,
+		Selected:     tcell.ColorWhite,
+		SelectedText: tcell.ColorBlack,
+		Done:         tcell.ColorGray,
+		Description:  tcell.ColorYellow,
+		ColumnHeader: tcell.ColorWhite,
+		TreeBoard:    tcell.ColorGreen,
+		TreeColumn:   tcell.ColorWhite,
+		TreeTask:     tcell.ColorWhite,
+		Field:        tcell.ColorBlue,
+		FieldText:    tcell.ColorWhite,
+		Warning:      tcell.ColorYellow,
+		Error:        tcell.ColorRed,
+	},
+	"light": {
+		Background:   tcell.ColorWhite,
+		Text:         tcell.ColorBlack,
+		Border:       tcell.ColorGray,
+		Title:        tcell.ColorBlack,
+		Selected:     tcell.ColorNavy,
+		SelectedText: tcell.ColorWhite,
+		Done:         tcell.ColorGray,
+		Description:  tcell.ColorOlive,
+		ColumnHeader: tcell.ColorNavy,
+		TreeBoard:    tcell.ColorGreen,
+		TreeColumn:   tcell.ColorBlack,
+		TreeTask:     tcell.ColorDimGray,
+		Field:        tcell.ColorSilver,
+		FieldText:    tcell.ColorBlack,
+		Warning:      tcell.ColorOlive,
+		Error:        tcell.ColorMaroon,
+	},
+	"high-contrast": {
+		Background:   tcell.ColorBlack,
+		Text:         tcell.ColorWhite,
+		Border:       tcell.ColorYellow,
+		Title:        tcell.ColorYellow,
+		Selected:     tcell.ColorYellow,
+		SelectedText: tcell.ColorBlack,
+		Done:         tcell.ColorSilver,
+		Description:  tcell.ColorAqua,
+		ColumnHeader: tcell.ColorAqua,
+		TreeBoard:    tcell.ColorLime,
+		TreeColumn:   tcell.ColorWhite,
+		TreeTask:     tcell.ColorWhite,
+		Field:        tcell.ColorWhite,
+		FieldText:    tcell.ColorBlack,
+		Warning:      tcell.ColorYellow,
+		Error:        tcell.ColorRed,
+	},
+	"monochrome": {
+		Background:   tcell.ColorDefault,
+		Text:         tcell.ColorDefault,
+		Border:       tcell.ColorDefault,
+		Title:        tcell.ColorDefault,
+		Selected:     tcell.ColorDefault,
+		SelectedText: tcell.ColorDefault,
+		Done:         tcell.ColorDefault,
+		Description:  tcell.ColorDefault,
+		ColumnHeader: tcell.ColorDefault,
+		TreeBoard:    tcell.ColorDefault,
+		TreeColumn:   tcell.ColorDefault,
+		TreeTask:     tcell.ColorDefault,
+		Field:        tcell.ColorDefault,
+		FieldText:    tcell.ColorDefault,
+		Warning:      tcell.ColorDefault,
+		Error:        tcell.ColorDefault,
+		Monochrome:   true,
+	},
+}
+
+// themeColors maps the color names used in the configuration to the
+// theme colors.
+func (th *Theme) themeColors() map[string]*tcell.Color {
+	return map[string]*tcell.Color{
+		"background":    &th.Background,
+		"text":          &th.Text,
+		"border":        &th.Border,
+		"title":         &th.Title,
+		"selected":      &th.Selected,
+		"selected-text": &th.SelectedText,
+		"done":          &th.Done,
+		"description":   &th.Description,
+		"column-header": &th.ColumnHeader,
+		"tree-board":    &th.TreeBoard,
+		"tree-column":   &th.TreeColumn,
+		"tree-task":     &th.TreeTask,
+		"field":         &th.Field,
+		"field-text":    &th.FieldText,
+		"warning":       &th.Warning,
+		"error":         &th.Error,
+	}
+}
+
+// resolveTheme returns the named theme, or the default theme if name
+// is empty, with the given colors overridden. Colors are color names or
+// hex values such as "#ff8700". If the NO_COLOR environment variable is
+// set, the monochrome theme is used regardless.
+func resolveTheme(name string, colors map[string]string) (Theme, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		return themes["monochrome"], nil
+	}
+	if name == "" {
+		name = "default"
+	}
+	th, ok := themes[name]
+	if !ok {
+		var names []string
+		for n := range themes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Theme{}, fmt.Errorf("unknown theme %q, want one of %s", name, strings.Join(names, ", "))
+	}
+
+	fields := th.themeColors()
+	for key, value := range colors {
+		field, ok := fields[key]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme color %q", key)
+		}
+		color := tcell.GetColor(value)
+		if color == tcell.ColorDefault && !strings.EqualFold(value, "default") {
+			return Theme{}, fmt.Errorf("invalid color %q for %q", value, key)
+		}
+		*field = color
+	}
+	return th, nil
+}
+
+// applyTheme sets the default styles of the tview primitives. It must
+// be called before any primitive is created.
+func (t *TUI) applyTheme() {
+	th := t.theme
+	tview.Styles = tview.Theme{
+		PrimitiveBackgroundColor:    th.Background,
+		ContrastBackgroundColor:     th.Field,
+		MoreContrastBackgroundColor: th.Selected,
+		BorderColor:                 th.Border,
+		TitleColor:                  th.Title,
+		GraphicsColor:               th.Border,
+		PrimaryTextColor:            th.Text,
+		SecondaryTextColor:          th.Text,
+		TertiaryTextColor:           th.Description,
+		InverseTextColor:            th.SelectedText,
+		ContrastSecondaryTextColor:  th.FieldText,
+	}
+}
+
+// selectedStyle returns the style of selected table rows.
+func (th Theme) selectedStyle() tcell.Style {
+	if th.Monochrome {
+		return tcell.StyleDefault.Reverse(true)
+	}
+	return tcell.StyleDefault.Foreground(th.SelectedText).Background(th.Selected)
+}
+
+// doneStyle returns the style of done tasks.
+func (th Theme) doneStyle() tcell.Style {
+	if th.Monochrome {
+		return tcell.StyleDefault.Dim(true)
+	}
+	return tcell.StyleDefault.Foreground(th.Done).Background(th.Background)
+}
+
+// descStyle returns the style of task descriptions.
+func (th Theme) descStyle() tcell.Style {
+	if th.Monochrome {
+		return tcell.StyleDefault.Italic(true)
+	}
+	return tcell.StyleDefault.Foreground(th.Description).Background(th.Background)
+}
+
+// codeColor returns the color of code in task descriptions, or an
+// empty string if code is shown reversed instead.
+func (th Theme) codeColor() string {
+	if th.Monochrome || th.Text.Hex() < 0 {
+		return ""
+	}
+	return fmt.Sprintf("#%06x", th.Text.Hex())
+}
+
+// laneStyle returns the style of swimlane headers.
+func (th Theme) laneStyle() tcell.Style {
+	if th.Monochrome {
+		return tcell.StyleDefault.Bold(true)
+	}
+	return tcell.StyleDefault.Foreground(th.ColumnHeader).Background(th.Background)
+}
+
+// newForm returns a bordered form styled by the theme.
+func (t *TUI) newForm() *tview.Form {
+	form := tview.NewForm()
+	form.SetBorder(true)
+	if t.theme.Monochrome {
+		form.SetFieldBackgroundColor(tcell.ColorDefault).
+			SetButtonStyle(tcell.StyleDefault).
+			SetButtonActivatedStyle(tcell.StyleDefault.Reverse(true))
+	}
+	return form
+}
+
+// newColumnTable returns a table for a board column styled by the
+// theme.
+func (t *TUI) newColumnTable() *tview.Table {
+	table := tview.NewTable().
+		SetSelectable(false, false). // No selection by default
+		SetSelectedStyle(t.theme.selectedStyle())
+	table.SetBorder(true)
+	table.SetTitleColor(t.theme.ColumnHeader)
+	return table
+}
+
+// treeMarker prefixes the current tree node in monochrome themes.
+const treeMarker = "› "
+
+// markTreeNode moves the current node marker of monochrome themes to
+// the given node.
+func (t *TUI) markTreeNode(node *tview.TreeNode) {
+	if !t.theme.Monochrome {
+		return
+	}
+	if t.markedNode != nil {
+		t.markedNode.SetText(strings.TrimPrefix(t.markedNode.GetText(), treeMarker))
+	}
+	t.markedNode = node
+	if node != nil && !strings.HasPrefix(node.GetText(), treeMarker) {
+		node.SetText(treeMarker + node.GetText())
+	}
+}
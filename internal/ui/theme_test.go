@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestResolveTheme(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	th, err := resolveTheme("light", map[string]string{"border": "#ff0000", "done": "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Border != tcell.NewHexColor(0xff0000) || th.Done != tcell.ColorDefault || th.Text != tcell.ColorBlack {
+		t.Errorf("got theme %+v, want light theme with red border and default done color", th)
+	}
+
+	tests := []struct {
+		name   string
+		colors map[string]string
+		want   string
+	}{
+		{"neon", nil, `unknown theme "neon", want one of default, high-contrast, light, monochrome`},
+		{"", map[string]string{"borders": "red"}, `unknown theme color "borders"`},
+		{"", map[string]string{"border": "reddish"}, `invalid color "reddish" for "border"`},
+	}
+	for _, tt := range tests {
+		if _, err := resolveTheme(tt.name, tt.colors); err == nil || err.Error() != tt.want {
+			t.Errorf("resolveTheme(%q, %v) = %v, want %s", tt.name, tt.colors, err, tt.want)
+		}
+	}
+}
+
+// TestResolveThemeNoColor checks that NO_COLOR selects the monochrome
+// theme over the configured one.
+func TestResolveThemeNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	th, err := resolveTheme("high-contrast", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !th.Monochrome {
+		t.Errorf("got theme %+v, want monochrome", th)
+	}
+}
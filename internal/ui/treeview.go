@@ -0,0 +1,330 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// treeIndent is the number of columns each tree level is indented by,
+// with graphics and the default node indent.
+const treeIndent = 3
+
+// treeScrollStep is the number of columns scrolled horizontally at once.
+const treeScrollStep = 4
+
+// A treeView is a tview.TreeView that scrolls horizontally, marks
+// truncated nodes with an ellipsis and can show a subtree only.
+type treeView struct {
+	*tview.TreeView
+	root     *tview.TreeNode            // root of the whole tree
+	offsetX  int                        // number of columns scrolled to the right
+	width    int                        // inner width of the last draw
+	selected func(node *tview.TreeNode) // called when a node is selected
+}
+
+// newTreeView returns a tree view of the tree with the given root.
+func newTreeView(root *tview.TreeNode) *treeView {
+	return &treeView{
+		TreeView: tview.NewTreeView().SetRoot(root).SetCurrentNode(root),
+		root:     root,
+	}
+}
+
+// SetSelectedFunc sets the function called when a node is selected with
+// Enter or a double-click.
+func (tv *treeView) SetSelectedFunc(handler func(node *tview.TreeNode)) {
+	tv.selected = handler
+	tv.TreeView.SetSelectedFunc(handler)
+}
+
+// GetRoot returns the root of the whole tree, even while a subtree is
+// focused.
+func (tv *treeView) GetRoot() *tview.TreeNode { return tv.root }
+
+// focusSubtree shows the subtree of the given node only, or the whole
+// tree if node is nil.
+func (tv *treeView) focusSubtree(node *tview.TreeNode) {
+	if node == nil {
+		node = tv.root
+	}
+	tv.TreeView.SetRoot(node)
+	tv.offsetX = 0
+}
+
+// subtreeFocused reports whether a subtree is shown.
+func (tv *treeView) subtreeFocused() bool { return tv.TreeView.GetRoot() != tv.root }
+
+// scroll scrolls the tree the given number of columns to the right, or
+// to the left if negative.
+func (tv *treeView) scroll(columns int) {
+	tv.offsetX += columns
+	if max := tv.contentWidth() - tv.width; tv.offsetX > max {
+		tv.offsetX = max
+	}
+	if tv.offsetX < 0 {
+		tv.offsetX = 0
+	}
+}
+
+// scrollToCurrent scrolls horizontally so that the start of the current
+// node's text is visible.
+func (tv *treeView) scrollToCurrent() {
+	node := tv.GetCurrentNode()
+	if node == nil || tv.width == 0 {
+		return
+	}
+	x := tv.depth(node) * treeIndent
+	visible := tv.width / 2
+	if x < tv.offsetX || x > tv.offsetX+tv.width-visible {
+		tv.offsetX = x - tv.width/4
+		if tv.offsetX < 0 {
+			tv.offsetX = 0
+		}
+	}
+}
+
+// depth returns the depth of a node below the shown root, or 0 if it
+// isn't shown.
+func (tv *treeView) depth(target *tview.TreeNode) int {
+	depth := 0
+	tv.TreeView.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+		if node == target {
+			depth = node.GetLevel()
+			return false
+		}
+		return true
+	})
+	return depth
+}
+
+// contentWidth returns the width of the widest visible node.
+func (tv *treeView) contentWidth() int {
+	width := 0
+	var walk func(node *tview.TreeNode, depth int)
+	walk = func(node *tview.TreeNode, depth int) {
+		if w := depth*treeIndent + tview.TaggedStringWidth(node.GetText()); w > width {
+			width = w
+		}
+		if node.IsExpanded() {
+			for _, child := range node.GetChildren() {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(tv.TreeView.GetRoot(), 0)
+	return width
+}
+
+// Draw draws the tree scrolled horizontally. Nodes running off the
+// right edge end with an ellipsis.
+func (tv *treeView) Draw(screen tcell.Screen) {
+	// Show the whole tree again if the shown subtree was removed.
+	if tv.subtreeFocused() && !contains(tv.root, tv.TreeView.GetRoot()) {
+		tv.focusSubtree(nil)
+	}
+
+	// Draw the border and title in place, then the tree shifted and
+	// clipped to the inside of the border. The tree is drawn wide enough
+	// for its widest node, so that any text drawn beyond the border shows
+	// that a node is truncated.
+	tv.Box.DrawForSubclass(screen, tv)
+	x, y, width, height := tv.GetRect()
+	ix, iy, iw, ih := tv.GetInnerRect()
+	tv.width = iw
+	wide := width + tv.offsetX + tv.contentWidth()
+	clip := &clipScreen{
+		Screen:    screen,
+		width:     x + wide,
+		offset:    tv.offsetX,
+		left:      ix,
+		top:       iy,
+		right:     ix + iw,
+		bottom:    iy + ih,
+		truncated: make(map[int]bool),
+	}
+	tv.SetRect(x, y, wide, height)
+	tv.TreeView.Draw(clip)
+	tv.SetRect(x, y, width, height)
+
+	for row := range clip.truncated {
+		_, _, style, _ := screen.GetContent(ix+iw-1, row)
+		screen.SetContent(ix+iw-1, row, '…', nil, style)
+	}
+}
+
+// MouseHandler returns the mouse handler of the tree. Unlike that of
+// tview.TreeView, a click selects a node without expanding or
+// collapsing it, which takes a double-click.
+func (tv *treeView) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return tv.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		handler := tv.TreeView.MouseHandler()
+		switch action {
+		case tview.MouseLeftDown:
+			// Focus the tree view itself rather than the embedded one.
+			if x, y := event.Position(); tv.InRect(x, y) {
+				setFocus(tv)
+				return true, nil
+			}
+		case tview.MouseLeftClick:
+			tv.TreeView.SetSelectedFunc(nil)
+			defer tv.TreeView.SetSelectedFunc(tv.selected)
+		case tview.MouseLeftDoubleClick:
+			// A tview.TreeView selects the node on every click.
+			action = tview.MouseLeftClick
+		}
+		return handler(action, event, setFocus)
+	})
+}
+
+// contains reports whether the tree below root contains the node.
+func contains(root, target *tview.TreeNode) bool {
+	found := false
+	root.Walk(func(node, _ *tview.TreeNode) bool {
+		if node == target {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// A clipScreen shifts drawing to the left by an offset and clips it to
+// a rectangle. It records the rows with text clipped on the right.
+type clipScreen struct {
+	tcell.Screen
+	width                    int // width reported by Size
+	offset                   int
+	left, top, right, bottom int
+	truncated                map[int]bool
+}
+
+// SetContent sets the content of a cell, shifted and clipped.
+func (c *clipScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	x -= c.offset
+	if y < c.top || y >= c.bottom {
+		return
+	}
+	if x >= c.right && mainc != ' ' {
+		c.truncated[y] = true
+	}
+	if x < c.left || x >= c.right {
+		return
+	}
+	c.Screen.SetContent(x, y, mainc, combc, style)
+}
+
+// Size returns the size of the screen, widened so that text beyond its
+// right edge is drawn and can be shifted in.
+func (c *clipScreen) Size() (int, int) {
+	_, height := c.Screen.Size()
+	return c.width, height
+}
+
+// GetContent returns the content of a cell, shifted.
+func (c *clipScreen) GetContent(x, y int) (rune, []rune, tcell.Style, int) {
+	x -= c.offset
+	if x < c.left || x >= c.right {
+		return ' ', nil, tcell.StyleDefault, 1
+	}
+	return c.Screen.GetContent(x, y)
+}
+
+// nodeKey returns the key under which the collapsed state of a board or
+// column node is saved, or an empty string for other nodes.
+func (t *TUI) nodeKey(node *tview.TreeNode) string {
+	switch ref := node.GetReference().(type) {
+	case NodeRef:
+		return fmt.Sprintf("board:%d", ref.ID)
+	case *tasks.BoardColumn:
+		for _, b := range t.treeData.Boards() {
+			for i := range b.Columns {
+				if &b.Columns[i] == ref {
+					return colKeyPrefix(b.GetID()) + strconv.Itoa(i)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// colKeyPrefix returns the start of the keys of the column nodes of a
+// board, which end with the index of the column.
+func colKeyPrefix(boardID int) string {
+	return fmt.Sprintf("board:%d/column:", boardID)
+}
+
+// shiftCollapsedCols keeps the collapsed state of the column nodes of a
+// board with their columns after a column is inserted at the given
+// index, for a delta of 1, or removed from it, for a delta of -1. The
+// state of a removed column is dropped.
+func (t *TUI) shiftCollapsedCols(b *tasks.Board, idx, delta int) {
+	prefix := colKeyPrefix(b.GetID())
+	keys := t.treeData.Collapsed[:0]
+	for _, key := range t.treeData.Collapsed {
+		if !strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+			continue
+		}
+		i, err := strconv.Atoi(key[len(prefix):])
+		switch {
+		case err != nil || i < idx:
+		case delta < 0 && i == idx:
+			continue
+		default:
+			key = prefix + strconv.Itoa(i+delta)
+		}
+		keys = append(keys, key)
+	}
+	t.treeData.Collapsed = keys
+}
+
+// restoreExpanded collapses a new board or column node if it was
+// collapsed when last saved.
+func (t *TUI) restoreExpanded(node *tview.TreeNode) {
+	if key := t.nodeKey(node); key != "" && t.treeData.IsCollapsed(key) {
+		t.setExpanded(node, false)
+	}
+}
+
+// nodeLevel returns the level of a node in the whole tree, where the
+// root is level 0 and root boards are level 1, regardless of the shown
+// subtree. It returns -1 if the node isn't in the tree.
+func (t *TUI) nodeLevel(target *tview.TreeNode) int {
+	level := -1
+	var walk func(node *tview.TreeNode, depth int) bool
+	walk = func(node *tview.TreeNode, depth int) bool {
+		if node == target {
+			level = depth
+			return true
+		}
+		for _, child := range node.GetChildren() {
+			if walk(child, depth+1) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(t.tree.GetRoot(), 0)
+	return level
+}
+
+// runFocusSubtree shows the subtree of the current tree node only, or
+// the whole tree again if a subtree is shown.
+func runFocusSubtree(t *TUI, _ context, _ []string) error {
+	if t.tree.subtreeFocused() {
+		t.tree.focusSubtree(nil)
+		return nil
+	}
+	node := t.tree.GetCurrentNode()
+	if len(node.GetChildren()) == 0 {
+		return fmt.Errorf("%q has nothing beneath it", node.GetText())
+	}
+	t.setExpanded(node, true)
+	t.tree.focusSubtree(node)
+	return nil
+}
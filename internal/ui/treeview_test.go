@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// drawTree draws a tree view on a simulation screen and returns its
+// rows.
+func drawTree(tv *treeView, width int) []string {
+	screen := tcell.NewSimulationScreen("")
+	screen.Init()
+	screen.SetSize(width, 2)
+	tv.SetRect(0, 0, width, 2)
+	tv.Draw(screen)
+	screen.Show()
+	cells, w, h := screen.GetContents()
+	rows := make([]string, h)
+	for y := 0; y < h; y++ {
+		var b strings.Builder
+		for x := 0; x < w; x++ {
+			b.WriteString(string(cells[y*w+x].Runes))
+		}
+		rows[y] = strings.TrimRight(b.String(), " ")
+	}
+	return rows
+}
+
+func TestTreeViewScroll(t *testing.T) {
+	root := tview.NewTreeNode("root")
+	root.AddChild(tview.NewTreeNode("a long node name"))
+	tv := newTreeView(root)
+
+	tests := []struct {
+		scroll int
+		want   string
+	}{
+		{0, "└──a long…"},
+		{4, " long nod…"},
+		{100, " node name"}, // stops once the widest node is visible
+	}
+	for _, tt := range tests {
+		tv.offsetX = 0
+		drawTree(tv, 10)
+		tv.scroll(tt.scroll)
+		rows := drawTree(tv, 10)
+		if got := rows[1]; got != tt.want {
+			t.Errorf("scroll(%d) = %q, want %q", tt.scroll, got, tt.want)
+		}
+	}
+}
+
+func TestTreeViewClick(t *testing.T) {
+	root := tview.NewTreeNode("root")
+	child := tview.NewTreeNode("child").AddChild(tview.NewTreeNode("grandchild"))
+	root.AddChild(child)
+	tv := newTreeView(root)
+	tv.SetSelectedFunc(func(node *tview.TreeNode) { node.SetExpanded(!node.IsExpanded()) })
+	drawTree(tv, 20)
+
+	click := func(action tview.MouseAction) {
+		event := tcell.NewEventMouse(5, 1, tcell.Button1, tcell.ModNone)
+		tv.MouseHandler()(action, event, func(tview.Primitive) {})
+	}
+	click(tview.MouseLeftClick)
+	if tv.GetCurrentNode() != child || !child.IsExpanded() {
+		t.Errorf("click: current node %q, expanded %t, want child, expanded", tv.GetCurrentNode().GetText(), child.IsExpanded())
+	}
+	click(tview.MouseLeftDoubleClick)
+	if tv.GetCurrentNode() != child || child.IsExpanded() {
+		t.Errorf("double-click: current node %q, expanded %t, want child, collapsed", tv.GetCurrentNode().GetText(), child.IsExpanded())
+	}
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
@@ -31,15 +30,56 @@ type TUI struct {
 	list     *tview.Table
 	taskData *tasks.TodoList
 
-	tree     *tview.TreeView
+	tree     *treeView
 	treeData *tasks.BoardTree
 
 	navStack []*tview.TreeNode
 
+	searchQuery string      // last search query
+	searchTask  *tasks.Task // task of the last visited search match
+
+	layout    *tview.Flex         // main grid above the filter bar
+	filterBar *tview.InputField   // input for the active filter query
+	filter    *tasks.Query        // active filter, nil when not filtering
+	queries   *tasks.SavedQueries // queries saved by name
+
+	theme      Theme           // colors of the user interface
+	markedNode *tview.TreeNode // tree node marked as current by monochrome themes
+
+	bindings map[context]map[string]binding // key bindings by context and key name
+	cmdLine  *commandLine                   // ex-style command line
+	save     func() error                   // saves the todo list and boards
+
+	skipLeafConfirm bool // delete tasks without a sub-board without confirmation
+
+	statusBar  *tview.Flex     // messages on the left, location and counts on the right
+	statusMsg  *tview.TextView // transient message
+	statusInfo *tview.TextView // location, task counts and save state
+	statusGen  int             // incremented with every message, to expire the latest only
+	savedState []byte          // snapshot of the data when last saved
+	dirty      bool            // whether the data differs from savedState
+	checkDirty bool            // whether to compare the data with savedState on the next draw
+
+	breadcrumb     *tview.TextView // path of the displayed board
+	crumbPath      string          // path of the displayed board, empty if none
+	crumbPrevFocus tview.Primitive // primitive focused before the breadcrumb
+	crumbSelecting bool            // breadcrumb segments are being selected with keys
+	termTitle      string          // terminal title set last, empty if never set
+
+	dragging bool // a board task is being dragged with the mouse
+	dragCol  int  // column the task is dragged from
+	dragRow  int  // table row of the dragged task
+
+	state *State // state restored on startup, nil if none
+
 	board         *tview.Grid
 	boardCols     []*tview.Table
 	boardColsData []tasks.BoardColumn
 	focusedCol    int
+	firstCol      int  // first column shown when not all fit
+	minColWidth   int  // minimum width of a column, zero for the default
+	blockWIP      bool // refuse tasks exceeding WIP limits rather than warn
+	autoComplete  bool // move parent tasks to done once their sub-board is done
 	isEmptyTable  bool
 }
 
@@ -52,12 +92,20 @@ type NodeRef struct {
 func (t *TUI) Init(tl *tasks.TodoList, tree *tasks.BoardTree) {
 	t.taskData = tl
 	t.treeData = tree
+	if t.state != nil {
+		tree.Collapsed = t.state.Collapsed
+	}
+	if t.theme == (Theme{}) {
+		t.theme, _ = resolveTheme("", nil)
+	}
+	t.applyTheme()
 	t.InitApp()
 	t.InitList()
 	t.InitBoard()
 	t.InitTree()
 
 	t.Populate() // populate tui list and tree view
+	t.markSaved()
 
 	t.InitLeftPanel()
 	t.InitRightPanel()
@@ -71,31 +119,54 @@ func (t *TUI) Init(tl *tasks.TodoList, tree *tasks.BoardTree) {
 		AddItem(t.leftPanel, 0, 0, 1, 1, 0, 0, true).
 		AddItem(t.rightPanel, 0, 1, 1, 1, 0, 0, false)
 
-	// Add the main grid to page
-	t.pages = tview.NewPages().
-		AddPage("main", t.mainGrid, true, true)
+	t.initFilterBar()
+	t.initCommandLine()
+	t.layout = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(t.mainGrid, 0, 1, true).
+		AddItem(t.filterBar, 0, 0, false).
+		AddItem(t.statusBar, 1, 0, false).
+		AddItem(t.cmdLine, 0, 0, false)
 
+	// Add the main layout to page
+	t.pages = tview.NewPages().
+		AddPage("main", t.layout, true, true)
+
+	// Restore the state once the first draw has set the panel widths.
+	// Grids only know which of their items have focus after drawing them,
+	// so draw once more for the borders to show the restored focus.
+	go func() {
+		t.app.QueueUpdateDraw(t.restoreState)
+		t.app.Draw()
+	}()
 	if err := t.app.SetRoot(t.pages, true).Run(); err != nil {
 		panic(err)
 	}
+	t.restoreTerminalTitle()
 }
 
 // InitApp initializes the application.
 func (t *TUI) InitApp() {
 	t.app = tview.NewApplication()
+	t.initBindings()
+	t.initStatusBar()
 	t.appInputCapture()
+	t.initMouse()
 	// Update left and right panel size before drawing. This won't affect
 	// the current drawing, it sets the panel width variables for the next
 	// draw operation.
 	t.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		t.updateStatus()
+		t.updateTerminalTitle()
 		width, _ := screen.Size()
 		if t.zoomedIn {
 			t.leftPanelWidth = width
 			t.rightPanelWidth = width
-			return false
+		} else {
+			t.leftPanelWidth = int(float64(width)*0.2) - borderWidth
+			t.rightPanelWidth = width - t.leftPanelWidth
 		}
-		t.leftPanelWidth = int(float64(width)*0.2) - borderWidth
-		t.rightPanelWidth = width - t.leftPanelWidth
+		t.layoutBoard()
 		return false
 	})
 }
@@ -103,7 +174,8 @@ func (t *TUI) InitApp() {
 // InitList initializes the list.
 func (t *TUI) InitList() {
 	t.list = tview.NewTable().
-		SetSelectable(true, false)
+		SetSelectable(true, false).
+		SetSelectedStyle(t.theme.selectedStyle())
 	t.listInputCapture()
 }
 
@@ -117,42 +189,46 @@ func (t *TUI) InitBoard() {
 
 // InitTree initializes the tree.
 func (t *TUI) InitTree() {
-	root := tview.NewTreeNode("Board Trees")
-	t.tree = tview.NewTreeView().
-		SetRoot(root).
-		SetCurrentNode(root)
+	root := tview.NewTreeNode("Board Trees").
+		SetColor(t.theme.Text)
+	t.tree = newTreeView(root)
+	t.tree.SetChangedFunc(func(node *tview.TreeNode) {
+		t.markTreeNode(node)
+		t.tree.scrollToCurrent()
+	})
 	t.treeInputCapture()
 	t.tree.SetSelectedFunc(func(node *tview.TreeNode) {
-		if node.IsExpanded() {
-			node.Collapse()
-			switch ref := node.GetReference().(type) {
-			case NodeRef:
-				board, ok := t.getBoardRef(node)
-				if !ok {
-					return
-				}
-				node.SetText("▸ " + board.GetTitle())
-			case *tasks.BoardColumn:
-				node.SetText("▸ " + ref.GetTitle())
-			case *tasks.BoardTask:
-			}
-		} else {
-			node.Expand()
-			switch ref := node.GetReference().(type) {
-			case NodeRef:
-				board, ok := t.getBoardRef(node)
-				if !ok {
-					return
-				}
-				node.SetText("▾ " + board.GetTitle())
-			case *tasks.BoardColumn:
-				node.SetText("▾ " + ref.GetTitle())
-			case *tasks.BoardTask:
-			}
-		}
+		t.setExpanded(node, !node.IsExpanded())
 	})
 }
 
+// setExpanded expands or collapses a tree view node and updates the
+// arrow in front of its text accordingly.
+func (t *TUI) setExpanded(node *tview.TreeNode, expanded bool) {
+	node.SetExpanded(expanded)
+	if key := t.nodeKey(node); key != "" {
+		t.treeData.SetCollapsed(key, !expanded)
+	}
+	arrow := "▸ "
+	if expanded {
+		arrow = "▾ "
+	}
+	switch ref := node.GetReference().(type) {
+	case NodeRef:
+		board, ok := t.getBoardRef(node)
+		if !ok {
+			return
+		}
+		node.SetText(t.boardNodeText(arrow, board))
+	case *tasks.BoardColumn:
+		node.SetText(arrow + ref.GetTitle())
+	case *tasks.BoardTask:
+	}
+	if node == t.markedNode {
+		node.SetText(treeMarker + node.GetText())
+	}
+}
+
 // InitLeftPanel initializes the left panel.
 func (t *TUI) InitLeftPanel() {
 	width := 25
@@ -174,6 +250,7 @@ func (t *TUI) InitRightPanel() {
 		SetRows(0).
 		SetColumns(0)
 	t.rightPanel.SetBorder(false)
+	t.initBreadcrumb()
 	t.showTreeView()
 }
 
@@ -183,6 +260,10 @@ func (t *TUI) showBoard(b *tasks.Board) {
 	t.board.Clear()
 	t.boardCols = nil // Reset columns
 	t.boardColsData = b.GetColumns()
+	t.treeData.SetCurrentBoardID(b.GetID())
+	t.updateBreadcrumb(b)
+	t.rightPanel.SetRows(1, 0).
+		AddItem(t.breadcrumb, 0, 0, 1, 1, 0, 0, false)
 
 	t.isEmptyTable = false
 	if len(t.boardColsData) == 0 {
@@ -193,7 +274,7 @@ func (t *TUI) showBoard(b *tasks.Board) {
 		noColTable.SetCell(0, 0, cell)
 
 		t.board.AddItem(noColTable, 0, 0, 1, 1, 0, 0, false)
-		t.rightPanel.AddItem(t.board, 0, 0, 1, 1, 0, 0, true)
+		t.rightPanel.AddItem(t.board, 1, 0, 1, 1, 0, 0, true)
 		t.app.SetFocus(t.rightPanel)
 		t.isEmptyTable = true
 		return
@@ -201,20 +282,21 @@ func (t *TUI) showBoard(b *tasks.Board) {
 
 	t.rightPanel.SetTitle(b.GetTitle())
 
-	// Create a table for each column in the board
+	// Create a table for each column in the board. The columns that fit
+	// are added to the board grid by layoutBoard.
+	t.focusedCol = 0
+	t.firstCol = 0
+	for range t.boardColsData {
+		t.boardCols = append(t.boardCols, t.newColumnTable())
+	}
 	for i := range t.boardColsData {
-		table := tview.NewTable().
-			SetSelectable(false, false) // No selection by default
-		table.SetBorder(true)
-
-		t.boardCols = append(t.boardCols, table)
-		t.updateColumn(i)
-		t.board.AddItem(table, 0, i, 1, 1, 0, 0, true)
+		t.fillColumn(i)
 	}
+	t.layoutBoard()
 
 	// Set right panel content to the board grid. This will override the
 	// tree view being displayed.
-	t.rightPanel.AddItem(t.board, 0, 0, 1, 1, 0, 0, true)
+	t.rightPanel.AddItem(t.board, 1, 0, 1, 1, 0, 0, true)
 
 	// Assert focus on the right panel. This is needed for board input
 	// capture to work.
@@ -223,59 +305,95 @@ func (t *TUI) showBoard(b *tasks.Board) {
 }
 
 // updateColumn clears the given column of the board and updates the
-// given column's contents.
+// given column's contents. Lanes line up across the columns of a board
+// with lanes, so every column is updated there.
 func (t *TUI) updateColumn(colIdx int) {
+	if t.boardLanes() != nil {
+		for i := range t.boardCols {
+			t.fillColumn(i)
+		}
+	} else {
+		t.fillColumn(colIdx)
+	}
+	// The tasks of the column count towards the progress of the board.
+	if b := t.nodeBoard(t.tree.GetCurrentNode()); b != nil {
+		t.refreshProgress(b)
+	}
+}
+
+// fillColumn clears the given column of the board and fills it with its
+// tasks.
+func (t *TUI) fillColumn(colIdx int) {
 	col := &t.boardColsData[colIdx]
 	table := t.boardCols[colIdx]
 
 	table.Clear()
-	table.SetTitle(col.GetTitle())
+	if b := t.nodeBoard(t.tree.GetCurrentNode()); b != nil {
+		if err := t.treeData.PrioritizeColumn(b, colIdx); err != nil {
+			t.errorf("Failed to prioritize column tasks: %v", err)
+		}
+	}
+	table.SetTitle(columnTitle(*col))
+	if limit := col.GetWIPLimit(); limit > 0 && len(col.GetTasks()) > limit {
+		table.SetTitleColor(t.theme.Warning)
+	} else {
+		table.SetTitleColor(t.theme.ColumnHeader)
+	}
 
+	if col.GetCollapsed() {
+		t.updateCollapsedColumn(table, colIdx)
+		return
+	}
 	if len(col.GetTasks()) == 0 {
 		table.SetCellSimple(0, 0, "No tasks available")
 		return
 	}
 
-	currentRow := 0
-	for _, task := range col.GetTasks() {
-		task := task
-		prefix := ""
-		if task.GetHasChild() {
-			prefix = "# "
-		}
-		name := prefix + task.GetName()
-		table.SetCellSimple(currentRow, 0, name)
-
-		// If task show description status is set to true, add the task
-		// description to the list.
-		if task.GetShowDesc() {
-			lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
-			wrappedDesc := WordWrap(task.GetDesc(), lineWidth)
-			for _, line := range wrappedDesc {
-				currentRow++
-				table.SetCell(currentRow, 0, tview.NewTableCell(line).
-					SetAlign(tview.AlignLeft).
-					SetSelectable(false).
-					SetTextColor(tcell.ColorYellow))
+	rows := t.columnRows(colIdx, t.colWidth())
+	hasTask := false
+	for i, row := range rows {
+		switch {
+		case row.lane:
+			table.SetCell(i, 0, tview.NewTableCell(row.text).
+				SetSelectable(false).
+				SetStyle(t.theme.laneStyle()))
+		case row.task >= 0:
+			hasTask = true
+			task := col.Tasks[row.task]
+			text := task.GetName()
+			if task.GetHasChild() {
+				text = "# " + text + " " + tview.Escape(t.taskProgress(task))
 			}
+			text += checklistBadge(task.Task)
+			cell := tview.NewTableCell(text)
+			if task.GetIsDone() {
+				cell.SetStyle(t.theme.doneStyle())
+			}
+			table.SetCell(i, 0, cell)
+		default:
+			// A line of the description of the task above, or padding to
+			// line up the next lane.
+			table.SetCell(i, 0, tview.NewTableCell(row.text).
+				SetAlign(tview.AlignLeft).
+				SetSelectable(false).
+				SetStyle(t.theme.descStyle()))
 		}
-		currentRow++
 	}
-}
-
-// calcColWidth calculates the width of a single board column.
-func calcColWidth(rightPanelWidth, numCols int) int {
-	// Accounting for two borders: borderWidth + borderWidth = 4
-	return (rightPanelWidth / numCols) - 4
+	if !hasTask {
+		table.Clear()
+		table.SetCellSimple(0, 0, "No matching tasks")
+	}
 }
 
 // showTreeView clears the right panel and sets the tree view.
 func (t *TUI) showTreeView() {
 	t.rightPanel.Clear()
 	t.rightPanel.SetTitle("Tree Navigation")
+	t.crumbPath = ""
 	// Set right panel content to the tree view. This will override a
 	// board being displayed.
-	t.rightPanel.AddItem(t.tree, 0, 0, 1, 1, 0, 0, true)
+	t.rightPanel.SetRows(0).
+		AddItem(t.tree, 0, 0, 1, 1, 0, 0, true)
 
 	t.clearNavStack()
 
@@ -284,53 +402,81 @@ func (t *TUI) showTreeView() {
 	t.app.SetFocus(t.tree)
 }
 
+// boardShown reports whether the right panel shows a board rather than
+// the tree view.
+func (t *TUI) boardShown() bool { return t.crumbPath != "" }
+
 // calcTaskIdx returns the calculated task index in a given task slice.
 // This function takes into account whether the description for each
 // task is shown, which would occupy one or more rows in the task list
-// table.
+// table, and skips tasks hidden by the active filter.
 func (t *TUI) calcTaskIdx(row, colWidth int) int {
-	taskIdx := 0
-
-	// Iterate through each row up to the selected row
-	for i := 0; i < row; i++ {
-		task, err := t.taskData.GetTask(taskIdx)
-		if err != nil {
-			log.Printf("Failed to calculate list task index: %v\n", err)
-			return taskIdx
+	currentRow := 0
+	for i, task := range t.taskData.GetTasks() {
+		if !t.listTaskVisible(task) {
+			continue
 		}
-
-		// If the task description is being shown, skip the next row
+		if currentRow >= row {
+			return i
+		}
+		currentRow++
+		// If the task description is being shown, skip the row(s) meant
+		// for the task description.
 		if task.GetShowDesc() {
-			wd := WordWrap(task.GetDesc(), colWidth)
-			i += len(wd) // Skip the row(s) meant for task description
+			currentRow += len(taskDetails(task.Task, colWidth, t.theme.codeColor()))
 		}
-		taskIdx++
 	}
-	return taskIdx
+	return len(t.taskData.GetTasks())
 }
 
 // calcTaskIdxBoard returns the calculated task index in a given board
 // column. This function takes into account whether the description for each
-// task is shown, which would occupy one or more rows in the column table.
+// task is shown, which would occupy one or more rows in the column table,
+// skips tasks hidden by the active filter and the headers of lanes.
 func (t *TUI) calcTaskIdxBoard(row, colWidth int) int {
-	taskIdx := 0
-
-	// Iterate through each row up to the selected row
-	for i := 0; i < row; i++ {
-		task, err := t.boardColsData[t.focusedCol].GetTask(taskIdx)
-		if err != nil {
-			log.Printf("Failed to calculate board task index: %v\n", err)
-			return taskIdx
+	rows := t.columnRows(t.focusedCol, colWidth)
+	for i := row; i < len(rows); i++ {
+		if i >= 0 && rows[i].task >= 0 {
+			return rows[i].task
 		}
+	}
+	return len(t.boardColsData[t.focusedCol].GetTasks())
+}
 
-		// If the task description is being shown, skip the next row
+// calcRow returns the list table row of the task at the given index.
+// This is the inverse of [calcTaskIdx].
+func (t *TUI) calcRow(taskIdx, colWidth int) int {
+	row := 0
+	for i, task := range t.taskData.GetTasks() {
+		if i == taskIdx {
+			break
+		}
+		if !t.listTaskVisible(task) {
+			continue
+		}
 		if task.GetShowDesc() {
-			wd := WordWrap(task.GetDesc(), colWidth)
-			i += len(wd) // Skip the row(s) meant for task description
+			row += len(taskDetails(task.Task, colWidth, t.theme.codeColor()))
 		}
-		taskIdx++
+		row++
 	}
-	return taskIdx
+	return row
+}
+
+// calcRowBoard returns the table row of the task at the given index in
+// the focused board column. This is the inverse of [calcTaskIdxBoard].
+// For a task that isn't shown, it returns the row of the next task shown.
+func (t *TUI) calcRowBoard(taskIdx, colWidth int) int {
+	rows := t.columnRows(t.focusedCol, colWidth)
+	next := len(rows)
+	for i, row := range rows {
+		if row.task == taskIdx {
+			return i
+		}
+		if row.task > taskIdx && i < next {
+			next = i
+		}
+	}
+	return next
 }
 
 // filterAndUpdateList filters out past completed tasks, marks today's
@@ -342,6 +488,9 @@ func (t *TUI) calcTaskIdxBoard(row, colWidth int) int {
 // list is implemented.
 func (t *TUI) filterAndUpdateList(colWidth int) {
 	t.list.Clear()
+	if err := t.taskData.Prioritize(); err != nil {
+		t.errorf("Failed to prioritize list tasks: %v", err)
+	}
 
 	if len(t.taskData.GetTasks()) == 0 {
 		t.list.SetCellSimple(0, 0, "No tasks available")
@@ -350,6 +499,9 @@ func (t *TUI) filterAndUpdateList(colWidth int) {
 
 	currentRow := 0
 	for _, task := range t.taskData.GetTasks() {
+		if !t.listTaskVisible(task) {
+			continue
+		}
 		prefix := "[ []"
 
 		// If task if completed, then mark it complete.
@@ -358,61 +510,29 @@ func (t *TUI) filterAndUpdateList(colWidth int) {
 		}
 
 		// Add task name to the list
-		t.list.SetCellSimple(currentRow, 0, prefix+task.GetName())
+		cell := tview.NewTableCell(prefix + task.GetName() + checklistBadge(task.Task))
+		if task.GetIsDone() {
+			cell.SetStyle(t.theme.doneStyle())
+		}
+		t.list.SetCell(currentRow, 0, cell)
 
 		// If task show description status is set to true, add the task
-		// description to the list.
+		// description and checklist to the list.
 		if task.GetShowDesc() {
-			wd := WordWrap(task.Description, colWidth)
+			wd := taskDetails(task.Task, colWidth, t.theme.codeColor())
 			for _, line := range wd {
 				currentRow++
 				t.list.SetCell(currentRow, 0, tview.NewTableCell(line).
 					SetAlign(tview.AlignLeft).
 					SetSelectable(false).
-					SetTextColor(tcell.ColorYellow))
+					SetStyle(t.theme.descStyle()))
 			}
 		}
 		currentRow++
 	}
-}
-
-// WordWrap returns a slice of wrapped lines given the text to the specified
-// length, breaking at word boundaries.
-func WordWrap(text string, lineWidth int) []string {
-	// Break text into individual words, split by spaces.
-	words := strings.Fields(text)
-
-	// wrappedLines will hold the lines of text after they've been wrapped.
-	wrappedLines := []string{}
-
-	// currentLine holds the words for the line being constructed.
-	currentLine := ""
-
-	// Loop through each word in the words slice.
-	for _, word := range words {
-		// If adding the new word to the current line would make it too long,
-		// append currentLine to wrappedLines and start a new line.
-		if len(currentLine)+len(word)+1 > lineWidth {
-			wrappedLines = append(wrappedLines, currentLine)
-			currentLine = ""
-		}
-
-		// If the current line isn't empty, add a space before the new word.
-		if len(currentLine) > 0 {
-			currentLine += " "
-		}
-
-		// Append the new word to the current line.
-		currentLine += word
-	}
-
-	// Append any remaining text in currentLine to wrappedLines.
-	if len(currentLine) > 0 {
-		wrappedLines = append(wrappedLines, currentLine)
+	if currentRow == 0 {
+		t.list.SetCellSimple(0, 0, "No matching tasks")
 	}
-
-	// Return the slice of wrapped lines.
-	return wrappedLines
 }
 
 // Populate takes the tasks from the task list and populates the
@@ -447,9 +567,10 @@ func (t *TUI) addRootBoardToTree(board *tasks.Board) {
 	nr := NodeRef{ID: board.GetID(), Type: "Board"}
 	boardNode := tview.NewTreeNode("▾ " + board.GetTitle()).
 		SetReference(nr).
-		SetColor(tcell.ColorGreen).
+		SetColor(t.theme.TreeBoard).
 		SetSelectable(true)
 	t.tree.GetRoot().AddChild(boardNode)
+	t.restoreExpanded(boardNode)
 	// Add board's children
 	t.addBoardToTree(boardNode, board)
 }
@@ -461,8 +582,10 @@ func (t *TUI) addBoardToTree(n *tview.TreeNode, b *tasks.Board) {
 	for i := range columns {
 		columnNode := tview.NewTreeNode("▾ " + columns[i].GetTitle()).
 			SetReference(&columns[i]).
+			SetColor(t.theme.TreeColumn).
 			SetSelectable(true)
 		n.AddChild(columnNode)
+		t.restoreExpanded(columnNode)
 
 		t.addColToTree(&columns[i], columnNode)
 	}
@@ -476,21 +599,23 @@ func (t *TUI) addColToTree(col *tasks.BoardColumn, columnNode *tview.TreeNode) {
 		if task.GetHasChild() {
 			childBoard, err := t.treeData.GetBoard(task.GetChildID())
 			if err != nil {
-				log.Println("Failed to populate tree view:", err)
+				t.errorf("Failed to populate tree view: %v", err)
 				return
 			}
 			nr := NodeRef{ID: childBoard.GetID(), Type: "Board"}
-			childNode := tview.NewTreeNode("▾ " + childBoard.GetTitle()).
+			childNode := tview.NewTreeNode(t.boardNodeText("▾ ", childBoard)).
 				SetReference(nr).
-				SetColor(tcell.ColorGreen).
+				SetColor(t.theme.TreeBoard).
 				SetSelectable(true)
 			columnNode.AddChild(childNode)
+			t.restoreExpanded(childNode)
 			t.addBoardToTree(childNode, childBoard)
 			continue
 		}
 
 		taskNode := tview.NewTreeNode(task.GetName()).
 			SetReference(&task).
+			SetColor(t.theme.TreeTask).
 			SetSelectable(false)
 		columnNode.AddChild(taskNode)
 	}
@@ -500,26 +625,23 @@ func (t *TUI) addColToTree(col *tasks.BoardColumn, columnNode *tview.TreeNode) {
 // tview primitives.
 func (tui *TUI) appInputCapture() {
 	tui.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		tui.noteInput()
 		// If tview primitives for user input are currently focused, ignore
 		// any global input captures. This prevents application side effects.
 		// For example, this allows the user to type "q" in an input field
 		// without quitting the application.
 		switch tui.app.GetFocus().(type) {
-		case *tview.InputField, *tview.DropDown, *tview.Checkbox, *tview.Button:
+		case *tview.InputField, *tview.TextArea, *tview.DropDown, *tview.Checkbox, *tview.Button, *commandLine:
+			return event
+		}
+		// Likewise, ignore them while an overlay, such as the help, is open
+		// or a breadcrumb segment is being selected.
+		if name, _ := tui.pages.GetFrontPage(); name != "main" || tui.breadcrumb.HasFocus() {
 			return event
 		}
 
-		switch event.Key() {
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'q': // Quit the program
-				tui.app.Stop()
-			case 'z': // Toggle panel zoom
-				tui.toggleZoom()
-			}
-		case tcell.KeyTab: // Switch panel focus
-			tui.switchPanel()
-			return nil // Override the tab key
+		if tui.dispatch(event, ctxGlobal) {
+			return nil
 		}
 		return event
 	})
@@ -580,40 +702,8 @@ func (t *TUI) switchPanel() {
 // listInputCapture captures input interactions specific to the list.
 func (t *TUI) listInputCapture() {
 	t.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		row, _ := t.list.GetSelection()
-		idx := t.calcTaskIdx(row, t.leftPanelWidth)
-		switch event.Key() {
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'a': // create new task
-				form := t.createListForm(idx)
-				t.showModal(form)
-				return event
-			case 'e': // edit task
-				form, err := t.editListForm(idx)
-				if err != nil {
-					return event
-				}
-				t.showModal(form)
-			case 'x': // toggle task completion status
-				if err := t.toggleTaskDone(idx); err != nil {
-					return event
-				}
-			case 'y': // yank task
-				if err := t.yankListTask(idx); err != nil {
-					return event
-				}
-			case 'd': // delete task
-				if err := t.deleteListTask(idx); err != nil {
-					return event
-				}
-			case 'p': // paste task
-				t.pasteListTask(idx)
-			case ' ': // toggle task description
-				if err := t.toggleTaskDesc(idx); err != nil {
-					return event
-				}
-			}
+		if t.dispatch(event, ctxList) {
+			return nil
 		}
 		return event
 	})
@@ -633,9 +723,9 @@ func (t *TUI) toggleTaskDone(idx int) error {
 		if _, err := t.taskData.Remove(idx); err != nil {
 			return err
 		}
-		// Append task to end of slice and update task priorities
+		// Append task to end of slice and update its priority
 		t.taskData.Add(&task, len(t.taskData.GetTasks()))
-		t.taskData.UpdatePriorities(idx)
+		t.taskData.Reprioritize(len(t.taskData.GetTasks()) - 1)
 		task.SetFinished(time.Now()) // update done date
 	}
 	t.filterAndUpdateList(t.leftPanelWidth)
@@ -659,7 +749,6 @@ func (t *TUI) deleteListTask(idx int) error {
 		return err
 	}
 	t.taskData.SetBuff(task)
-	t.taskData.UpdatePriorities(idx)
 	t.filterAndUpdateList(t.leftPanelWidth)
 	return nil
 }
@@ -669,8 +758,44 @@ func (t *TUI) pasteListTask(idx int) {
 	task := t.taskData.Buffer()
 	cpy := task.Copy(t.taskData)
 	t.taskData.Add(&cpy, idx+1)
-	t.taskData.UpdatePriorities(idx + 1)
+	t.taskData.Reprioritize(t.taskData.IndexOf(cpy.Task))
+	t.filterAndUpdateList(t.leftPanelWidth)
+}
+
+// shownNeighbour returns the index of the task offset places after the
+// task at idx, or before it if offset is negative, among the n tasks for
+// which shown returns true. If there are fewer such tasks, it returns
+// the index of the last one in that direction, or idx if there is none.
+func shownNeighbour(n, idx, offset int, shown func(i int) bool) int {
+	step := 1
+	if offset < 0 {
+		step, offset = -1, -offset
+	}
+	to := idx
+	for i := idx + step; i >= 0 && i < n && offset > 0; i += step {
+		if shown(i) {
+			to = i
+			offset--
+		}
+	}
+	return to
+}
+
+// moveListTask moves a list task to the given index, changing its
+// priority, and keeps the cursor on the moved task. Tasks are only
+// moved while the list is ordered manually.
+func (t *TUI) moveListTask(from, to int) error {
+	task, err := t.taskData.GetTask(from)
+	if err != nil {
+		return nil
+	}
+	moved := task.Task
+	if err := t.taskData.Move(from, to); err != nil {
+		return err
+	}
 	t.filterAndUpdateList(t.leftPanelWidth)
+	t.list.Select(t.calcRow(t.taskData.IndexOf(moved), t.leftPanelWidth), 0)
+	return nil
 }
 
 // toggleTaskDesc toggles a list task description.
@@ -688,37 +813,8 @@ func (t *TUI) toggleTaskDesc(idx int) error {
 // view.
 func (t *TUI) treeInputCapture() {
 	t.tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'L': // Enter board
-			node := t.tree.GetCurrentNode()
-			board, ok := t.getBoardRef(node)
-			if !ok {
-				return event
-			}
-			t.showBoard(board)
-			t.push(node)
-		case 'a':
-			// If node is the root node, create a new root board.
-			node := t.tree.GetCurrentNode()
-			if node.GetLevel() == 0 {
-				t.showModal(t.createRootBoardForm())
-			}
-		case 'e':
-			// If node is root board, edit root board.
-			node := t.tree.GetCurrentNode()
-			if node.GetLevel() == 1 {
-				board, ok := t.getBoardRef(node)
-				if !ok {
-					return event
-				}
-				t.showModal(t.editRootBoardForm(board, node))
-			}
-		case 'y': // yank a root board
-			t.yankRootBoard()
-		case 'd': // Delete a root board
-			t.deleteRootBoard()
-		case 'p': // Paste buffered root board
-			t.pasteRootBoard()
+		if t.dispatch(event, ctxTree) {
+			return nil
 		}
 		return event
 	})
@@ -727,18 +823,18 @@ func (t *TUI) treeInputCapture() {
 // yankRootBoard yanks a root board.
 func (t *TUI) yankRootBoard() {
 	node := t.tree.GetCurrentNode()
-	if node.GetLevel() != 1 {
+	if t.nodeLevel(node) != 1 {
 		return
 	}
 	board, ok := t.getBoardRef(node)
 	if !ok {
-		log.Println("Failed to yank root board: current tree view node isn't of type Board.")
+		t.errorf("Failed to yank root board: current tree view node isn't of type Board")
 		return
 	}
 
 	b, err := t.treeData.GetBoard(board.GetID())
 	if err != nil {
-		log.Printf("Failed to remove root board: %v\n", err)
+		t.errorf("Failed to remove root board: %v", err)
 		return
 	}
 	// Buffer board
@@ -749,18 +845,18 @@ func (t *TUI) yankRootBoard() {
 // deleteRootBoard deletes a root board.
 func (t *TUI) deleteRootBoard() {
 	node := t.tree.GetCurrentNode()
-	if node.GetLevel() != 1 {
+	if t.nodeLevel(node) != 1 {
 		return
 	}
 	board, ok := t.getBoardRef(node)
 	if !ok {
-		log.Println("Failed to remove root board: current tree view node isn't of type Board.")
+		t.errorf("Failed to remove root board: current tree view node isn't of type Board")
 		return
 	}
 
 	b, err := t.treeData.RemoveRoot(board)
 	if err != nil {
-		log.Printf("Failed to remove root board: %v\n", err)
+		t.errorf("Failed to remove root board: %v", err)
 		return
 	}
 	// Buffer deleted board
@@ -772,19 +868,20 @@ func (t *TUI) deleteRootBoard() {
 
 	// Update and show tree view
 	t.tree.GetRoot().RemoveChild(node)
+	t.tree.SetCurrentNode(t.tree.GetRoot())
 }
 
 // pasteRootBoard reads buffered root board and pastes it.
 func (t *TUI) pasteRootBoard() {
 	node := t.tree.GetCurrentNode()
-	if node.GetLevel() != 0 {
+	if t.nodeLevel(node) != 0 {
 		return
 	}
 
 	board := t.treeData.BoardBuff.GetBoardBuff()
 	cpy, err := board.DeepCopy(nil, t.treeData, t.treeData.BoardBuff.GetChildBoards())
 	if err != nil {
-		log.Printf("Failed to paste board: %v\n", err)
+		t.errorf("Failed to paste board: %v", err)
 		return
 	}
 
@@ -799,102 +896,34 @@ func (t *TUI) pasteRootBoard() {
 // currently displayed board.
 func (t *TUI) boardInputCapture() {
 	t.board.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		// Input capture for the no columns table
-		if t.isEmptyTable {
-			switch event.Rune() {
-			case 'h': // go back to tree navigation
-				t.showTreeView()
-			case 'a': // add a new column
-				form, err := t.createColForm(t.focusedCol)
-				if err != nil {
-					log.Println("Failed to add a column to the board: ", err)
-					return event
-				}
-				t.showModal(form)
-			}
-			return event
-		}
-
-		focusedOnTable := false
-		rows, cols := t.boardCols[t.focusedCol].GetSelectable()
-		if rows == false && cols == false {
-			focusedOnTable = true
-		}
-		row, _ := t.boardCols[t.focusedCol].GetSelection()
-
-		switch event.Rune() {
-		case 'l': // move right
-			if t.focusedCol < len(t.boardCols)-1 {
-				t.boardCols[t.focusedCol].SetSelectable(false, false)
-				t.focusedCol++
-				t.boardCols[t.focusedCol].SetSelectable(true, false)
-
-				if focusedOnTable {
-					t.boardCols[t.focusedCol].SetSelectable(false, false)
-				}
-				t.app.SetFocus(t.boardCols[t.focusedCol])
-			}
-		case 'h': // move left
-			// If at the first column, switch back to TreeView
-			if t.focusedCol == 0 {
-				t.boardCols[t.focusedCol].SetSelectable(false, false)
-				t.showTreeView()
-				return event
-			}
-
-			t.boardCols[t.focusedCol].SetSelectable(false, false)
-			t.focusedCol--
-			t.boardCols[t.focusedCol].SetSelectable(true, false)
-
-			if focusedOnTable {
-				t.boardCols[t.focusedCol].SetSelectable(false, false)
-			}
-			t.app.SetFocus(t.boardCols[t.focusedCol])
-		case 'H':
-			t.navBack()
-		case '0':
-			t.boardCols[t.focusedCol].SetSelectable(false, false)
-			t.focusedCol = 0
-			t.boardCols[t.focusedCol].SetSelectable(true, false)
-
-			if focusedOnTable {
-				t.boardCols[t.focusedCol].SetSelectable(false, false)
-			}
-			t.app.SetFocus(t.boardCols[t.focusedCol])
-		case '$':
-			endColIdx := len(t.boardColsData) - 1
-			if t.focusedCol == endColIdx {
-				return event
-			}
-			t.boardCols[t.focusedCol].SetSelectable(false, false)
-			t.focusedCol = endColIdx
-			t.boardCols[t.focusedCol].SetSelectable(true, false)
-
-			if focusedOnTable {
-				t.boardCols[t.focusedCol].SetSelectable(false, false)
-			}
-			t.app.SetFocus(t.boardCols[t.focusedCol])
-		}
-
-		switch focusedOnTable {
-		case true:
-			t.boardColInputCapture(event)
-		case false:
-			t.boardTaskInputCapture(event, row)
+		if t.dispatch(event, t.focusContext()) {
+			return nil
 		}
-
 		return event
 	})
 }
 
+// focusColumn moves focus to the board column at the given index. Task
+// selection stays enabled only if it was enabled for the column that
+// had focus.
+func (t *TUI) focusColumn(idx int) {
+	rows, _ := t.boardCols[t.focusedCol].GetSelectable()
+	t.boardCols[t.focusedCol].SetSelectable(false, false)
+	t.focusedCol = idx
+	// There are no tasks to select in a collapsed column.
+	rows = rows && !t.boardColsData[idx].GetCollapsed()
+	t.boardCols[t.focusedCol].SetSelectable(rows, false)
+	t.app.SetFocus(t.boardCols[t.focusedCol])
+}
+
 // enterSubBoard enters a sub-board when viewing a board.
 // Assumption: Focus in currently on a task.
 func (t *TUI) enterSubBoard(row int) {
 	col := &t.boardColsData[t.focusedCol]
-	lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
+	lineWidth := t.colWidth()
 	task, err := col.GetTask(t.calcTaskIdxBoard(row, lineWidth))
 	if err != nil {
-		log.Printf("Failed to enter sub-board: %v\n", err)
+		t.errorf("Failed to enter sub-board: %v", err)
 		return
 	}
 
@@ -907,7 +936,7 @@ func (t *TUI) enterSubBoard(row int) {
 
 		childBoard, err := t.treeData.GetBoard(task.GetChildID())
 		if err != nil {
-			log.Println("Failed to enter sub-board: ", err)
+			t.errorf("Failed to enter sub-board: %v", err)
 			return
 		}
 
@@ -915,7 +944,7 @@ func (t *TUI) enterSubBoard(row int) {
 		for _, node := range parentNode.GetChildren() {
 			c, ok := getColRef(node)
 			if !ok {
-				log.Println("Failed to enter sub-board: tree view node isn't of type Column.")
+				t.errorf("Failed to enter sub-board: tree view node isn't of type Column")
 				return
 			}
 			if c == col {
@@ -934,7 +963,7 @@ func (t *TUI) enterSubBoard(row int) {
 				}
 			}
 		}
-		log.Println("Failed to update tree view: tree view column node not found")
+		t.errorf("Failed to update tree view: tree view column node not found")
 	}
 }
 
@@ -946,7 +975,7 @@ func (t *TUI) navBack() {
 	}
 	board, ok := t.getBoardRef(prevNode)
 	if !ok {
-		log.Println("Failed to navigate back to previous board: popped stack node doesn't reference a board")
+		t.errorf("Failed to navigate back to previous board: popped stack node doesn't reference a board")
 		return
 	}
 
@@ -954,33 +983,21 @@ func (t *TUI) navBack() {
 	t.showBoard(board)
 }
 
-// boardColInputCapture captures input interactions specific to the
-// currently focused board column.
-func (t *TUI) boardColInputCapture(e *tcell.EventKey) *tcell.EventKey {
-	switch e.Rune() {
-	case 'j': // move down
-		// Enable task selection
-		t.boardCols[t.focusedCol].SetSelectable(true, false)
-	case 'a':
-		// If focus is on the entire table, create a new board column to
-		// the right of the currently focused column.
-		form, err := t.createColForm(t.focusedCol)
-		if err != nil {
-			log.Println("Failed to add a column to the board: ", err)
-			return e
+// pickPrioritizer opens a picker of all task prioritizers, with the
+// current one listed first, and calls done with the selected name.
+func (t *TUI) pickPrioritizer(current string, done func(name string)) {
+	if current == "" {
+		current = tasks.Manual
+	}
+	items := []pickerItem{{Text: current + " (current)", Ref: current}}
+	for _, name := range tasks.Prioritizers() {
+		if name != current {
+			items = append(items, pickerItem{Text: name, Ref: name})
 		}
-		t.showModal(form)
-	case 'e': // edit board column
-		form := t.editColForm()
-		t.showModal(form)
-	case 'y': // Yank board coloum
-		t.yankBoardCol()
-	case 'd': // Delete and buffer board column
-		t.removeBoardCol()
-	case 'p': // Paste board column
-		t.pasteBoardCol()
 	}
-	return e
+	t.showPicker("Order Tasks By", items, func(item pickerItem) {
+		done(item.Ref.(string))
+	})
 }
 
 // yankBoardCol yanks and buffers a board column.
@@ -988,7 +1005,7 @@ func (t *TUI) yankBoardCol() {
 	parentNode := t.tree.GetCurrentNode()
 	_, ok := t.getBoardRef(parentNode)
 	if !ok {
-		log.Println("Failed to yank board column: current tree view node isn't of type Board.")
+		t.errorf("Failed to yank board column: current tree view node isn't of type Board")
 		return
 	}
 	t.treeData.ColBuff.Clear()
@@ -1003,7 +1020,7 @@ func (t *TUI) removeBoardCol() {
 	parentNode := t.tree.GetCurrentNode()
 	parentBoard, ok := t.getBoardRef(parentNode)
 	if !ok {
-		log.Println("Failed to remove board column: current tree view node isn't of type Board.")
+		t.errorf("Failed to remove board column: current tree view node isn't of type Board")
 		return
 	}
 	t.treeData.ColBuff.Clear()
@@ -1018,9 +1035,10 @@ func (t *TUI) removeBoardCol() {
 
 	col, err := parentBoard.RemoveColumn(t.focusedCol)
 	if err != nil {
-		log.Printf("Failed to remove board column: %v\n", err)
+		t.errorf("Failed to remove board column: %v", err)
 		return
 	}
+	t.shiftCollapsedCols(parentBoard, t.focusedCol, -1)
 	t.treeData.ColBuff.SetColumnBuff(col)
 
 	// Update and show board
@@ -1032,6 +1050,7 @@ func (t *TUI) removeBoardCol() {
 	// 2. Re-add column child nodes which now excludes the removes column
 	parentNode.ClearChildren()
 	t.addBoardToTree(parentNode, parentBoard)
+	t.refreshProgress(parentBoard)
 }
 
 func (t *TUI) pasteBoardCol() {
@@ -1039,7 +1058,7 @@ func (t *TUI) pasteBoardCol() {
 	node := t.tree.GetCurrentNode()
 	board, ok := t.getBoardRef(node)
 	if !ok {
-		log.Println("Couldn't paste column: current tree view node isn't of type Board.")
+		t.errorf("Couldn't paste column: current tree view node isn't of type Board")
 		return
 	}
 
@@ -1048,12 +1067,13 @@ func (t *TUI) pasteBoardCol() {
 
 	cpy, err := column.DeepCopy(t.treeData, board, t.treeData.ColBuff.GetChildBoards())
 	if err != nil {
-		log.Printf("Failed to paste board column: %v\n", err)
+		t.errorf("Failed to paste board column: %v", err)
 		return
 	}
 
 	// Insert column into board
 	board.InsertColumn(cpy, t.focusedCol+1)
+	t.shiftCollapsedCols(board, t.focusedCol+1, 1)
 
 	// Update board
 	t.showBoard(board)
@@ -1066,82 +1086,192 @@ func (t *TUI) pasteBoardCol() {
 	// column.
 	node.ClearChildren()
 	t.addBoardToTree(node, board)
+	t.refreshProgress(board)
 }
 
-// boardTaskInputCapture captures input interactions specific to the
-// current board task.
-func (t *TUI) boardTaskInputCapture(e *tcell.EventKey, row int) *tcell.EventKey {
-	if e.Rune() == 'L' {
-		t.enterSubBoard(row)
+// cycleBoardTask moves a task to the next column with wrap around.
+func (t *TUI) cycleBoardTask(row int) {
+	newColIdx := (t.focusedCol + 1) % len(t.boardColsData)
+	// If there is only one column for the current board, do nothing.
+	if newColIdx == t.focusedCol {
+		return
 	}
-	if e.Key() == tcell.KeyEnter {
-		t.cycleBoardTask(row)
+	t.moveBoardTask(row, newColIdx, false)
+}
+
+// shiftBoardTask moves a task the given number of columns to the right,
+// or to the left if negative, without wrap around.
+func (t *TUI) shiftBoardTask(row, offset int) {
+	newColIdx := t.focusedCol + offset
+	if newColIdx < 0 || newColIdx >= len(t.boardColsData) {
+		return
 	}
+	t.moveBoardTask(row, newColIdx, true)
+}
 
-	switch e.Rune() {
-	case 'k': // move up
-		if row == 0 {
-			// Disable task selection
-			t.boardCols[t.focusedCol].SetSelectable(false, false)
-		}
-	case 'a': // add board task underneath current task
-		lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
-		form := t.createBoardTaskForm(t.calcTaskIdxBoard(row, lineWidth))
-		t.showModal(form)
-	case 'e': // edit board tasks
-		lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
-		form, err := t.editBoardTaskForm(t.calcTaskIdxBoard(row, lineWidth))
-		if err != nil {
-			return e
-		}
-		t.showModal(form)
-	case 'y': // yank board task
-		t.yankBoardTask(row)
-	case 'd': // delete and buffer board task
-		t.removeBoardTask(row)
-	case 'p': // paste board task
-		t.pasteBoardTask(row)
-	case ' ': // Toggle task description
-		t.toggleBoardTaskDesc(row)
+// sendBoardTask moves a task directly to the column at the given index.
+func (t *TUI) sendBoardTask(row, colIdx int) {
+	if colIdx < 0 || colIdx >= len(t.boardColsData) || colIdx == t.focusedCol {
+		return
 	}
-	return e
+	t.moveBoardTask(row, colIdx, true)
 }
 
-// cycleBoardTask moves a task to the next column with wrap around.
-func (t *TUI) cycleBoardTask(row int) {
+// moveBoardTask moves a task to the given column of the current board.
+// The task is placed according to its priority so that it keeps its
+// relative position in the new column. If follow is true, focus moves
+// along with the task.
+func (t *TUI) moveBoardTask(row, newColIdx int, follow bool) {
 	parentNode := t.tree.GetCurrentNode()
 	board, ok := t.getBoardRef(parentNode)
 	if !ok {
-		log.Println("Failed to move board task: current tree view node isn't of type Board.")
+		t.errorf("Failed to move board task: current tree view node isn't of type Board")
 		return
 	}
 
-	col := &t.boardColsData[t.focusedCol]
-	newColIdx := (t.focusedCol + 1) % len(board.GetColumns())
-	// If there is only one column for the current board, do nothing.
-	if newColIdx == t.focusedCol {
+	lineWidth := t.colWidth()
+	idx := t.calcTaskIdxBoard(row, lineWidth)
+	task, err := t.boardColsData[t.focusedCol].GetTask(idx)
+	if err != nil {
+		t.errorf("Failed to move board task: %v", err)
 		return
 	}
-	newCol := &t.boardColsData[newColIdx]
+	if newColIdx != t.focusedCol {
+		if err := t.checkWIP(&t.boardColsData[newColIdx]); err != nil {
+			t.errorf("Failed to move board task: %v", err)
+			return
+		}
+	}
+	moved := task.Task
+	newIdx := t.boardColsData[newColIdx].PriorityIndex(task.GetPriority())
 
-	// Remove task from focused column
-	lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
-	idx := t.calcTaskIdxBoard(row, lineWidth)
-	task, err := col.Remove(idx)
-	if err != nil {
-		log.Printf("Failed to move board task: %v\n", err)
+	if err := t.treeData.MoveTask(board, t.focusedCol, idx, board, newColIdx, newIdx); err != nil {
+		t.errorf("Failed to move board task: %v", err)
 		return
 	}
 	t.updateColumn(t.focusedCol)
-
-	// Add task to next column
-	newCol.InsertTask(task, 0)
 	t.updateColumn(newColIdx)
 
+	// The task can't be selected in a collapsed column.
+	if follow && !t.boardColsData[newColIdx].GetCollapsed() {
+		t.boardCols[t.focusedCol].SetSelectable(false, false)
+		t.focusedCol = newColIdx
+		t.boardCols[t.focusedCol].SetSelectable(true, false)
+		newIdx = t.boardColsData[t.focusedCol].IndexOf(moved)
+		t.boardCols[t.focusedCol].Select(t.calcRowBoard(newIdx, lineWidth), 0)
+		t.app.SetFocus(t.boardCols[t.focusedCol])
+	}
+
 	// Update tree view to show moved task by clearing entire board and
 	// adding it back to the tree.
 	parentNode.ClearChildren()
 	t.addBoardToTree(parentNode, board)
+	t.completeParents(board)
+}
+
+// reorderBoardTask moves a task the given number of places down the
+// focused column, or up if negative, counting the tasks shown in its
+// lane, and keeps the cursor on the moved task. The target is clamped
+// to the column. Tasks are only moved while the column is ordered
+// manually.
+func (t *TUI) reorderBoardTask(row, offset int) error {
+	parentNode := t.tree.GetCurrentNode()
+	board, ok := t.getBoardRef(parentNode)
+	if !ok {
+		return errors.New("current tree view node isn't of type Board")
+	}
+	col := &t.boardColsData[t.focusedCol]
+	lineWidth := t.colWidth()
+	idx := t.calcTaskIdxBoard(row, lineWidth)
+	if idx < 0 || idx >= len(col.GetTasks()) {
+		return nil
+	}
+
+	// Hidden tasks and tasks of other lanes are skipped, so that the
+	// task moves past the tasks shown next to it.
+	lane := t.taskLane(col.Tasks[idx])
+	to := shownNeighbour(len(col.Tasks), idx, offset, func(i int) bool {
+		task := col.Tasks[i]
+		return t.boardTaskVisible(t.focusedCol, task) && t.taskLane(task) == lane
+	})
+	if to == idx {
+		return nil
+	}
+	moved := col.Tasks[idx].Task
+	if err := t.treeData.ReorderTask(board, t.focusedCol, idx, to); err != nil {
+		return err
+	}
+	t.updateColumn(t.focusedCol)
+	t.boardCols[t.focusedCol].Select(t.calcRowBoard(col.IndexOf(moved), lineWidth), 0)
+
+	parentNode.ClearChildren()
+	t.addBoardToTree(parentNode, board)
+	return nil
+}
+
+// pickTaskDestination opens a picker of every board column in the
+// board tree and moves the current task to the selected column.
+func (t *TUI) pickTaskDestination(row int) {
+	parentNode := t.tree.GetCurrentNode()
+	board, ok := t.getBoardRef(parentNode)
+	if !ok {
+		t.errorf("Failed to move board task: current tree view node isn't of type Board")
+		return
+	}
+	lineWidth := t.colWidth()
+	idx := t.calcTaskIdxBoard(row, lineWidth)
+	task, err := t.boardColsData[t.focusedCol].GetTask(idx)
+	if err != nil {
+		return
+	}
+	srcCol := t.focusedCol
+
+	var items []pickerItem
+	for _, b := range t.treeData.Boards() {
+		// Skip the task's own sub-board and every board nested beneath it.
+		if task.GetHasChild() && (b.GetID() == task.GetChildID() ||
+			t.treeData.IsDescendant(task.GetChildID(), b.GetID())) {
+			continue
+		}
+		path := t.treeData.PathString(b.GetID())
+		for i, col := range b.GetColumns() {
+			if b == board && i == srcCol {
+				continue
+			}
+			items = append(items, pickerItem{
+				Text: path + " › " + col.GetTitle(),
+				Ref:  columnLoc{board: b, col: i},
+			})
+		}
+	}
+
+	t.showPicker("Move Task To", items, func(item pickerItem) {
+		loc := item.Ref.(columnLoc)
+		if err := t.checkWIP(&loc.board.Columns[loc.col]); err != nil {
+			t.errorf("Failed to move board task: %v", err)
+			return
+		}
+		if err := t.treeData.MoveTask(board, srcCol, idx, loc.board, loc.col, 0); err != nil {
+			t.errorf("Failed to move board task: %v", err)
+			return
+		}
+		t.updateColumn(srcCol)
+		if loc.board == board {
+			t.updateColumn(loc.col)
+		}
+		t.app.SetFocus(t.boardCols[t.focusedCol])
+
+		// The moved task may carry a sub-board between boards anywhere in
+		// the tree, so rebuild the whole tree view.
+		t.reloadTree(board)
+		t.completeParents(board, loc.board)
+	})
+}
+
+// columnLoc identifies a column of a board.
+type columnLoc struct {
+	board *tasks.Board
+	col   int
 }
 
 // yankBoardTask deletes and buffers a board task.
@@ -1154,7 +1284,7 @@ func (t *TUI) yankBoardTask(row int) {
 	col := &t.boardColsData[t.focusedCol]
 
 	// Delete task from focused column
-	lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
+	lineWidth := t.colWidth()
 	idx := t.calcTaskIdxBoard(row, lineWidth)
 	task, err := col.GetTask(idx)
 	if err != nil {
@@ -1174,7 +1304,7 @@ func (t *TUI) removeBoardTask(row int) {
 	col := &t.boardColsData[t.focusedCol]
 
 	// Delete task from focused column
-	lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
+	lineWidth := t.colWidth()
 	idx := t.calcTaskIdxBoard(row, lineWidth)
 	task, err := col.Remove(idx)
 	if err != nil {
@@ -1190,7 +1320,6 @@ func (t *TUI) removeBoardTask(row int) {
 	}
 
 	// Update focused column
-	col.UpdatePriorities(idx)
 	t.updateColumn(t.focusedCol)
 
 	// Update tree view to show removed task by clearing entire board and
@@ -1198,6 +1327,7 @@ func (t *TUI) removeBoardTask(row int) {
 	// the column and adding it back results in a panic.
 	parentNode.ClearChildren()
 	t.addBoardToTree(parentNode, parentBoard)
+	t.completeParents(parentBoard)
 }
 
 // pasteBoardTask reads buffered task and pastes it.
@@ -1206,11 +1336,11 @@ func (t *TUI) pasteBoardTask(row int) {
 	parentNode := t.tree.GetCurrentNode()
 	board, ok := t.getBoardRef(parentNode)
 	if !ok {
-		log.Println("Couldn't paste task: current tree view node isn't of type Board.")
+		t.errorf("Couldn't paste task: current tree view node isn't of type Board")
 		return
 	}
 
-	lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
+	lineWidth := t.colWidth()
 	idx := t.calcTaskIdxBoard(row, lineWidth)
 
 	// Read from buffer
@@ -1219,23 +1349,27 @@ func (t *TUI) pasteBoardTask(row int) {
 	if task.Task == nil {
 		return
 	}
+	if err := t.checkWIP(&t.boardColsData[t.focusedCol]); err != nil {
+		t.errorf("Failed to paste board task: %v", err)
+		return
+	}
 
 	cpy, err := task.DeepCopy(t.treeData, board, t.treeData.TaskBuff.GetChildBoards())
 	if err != nil {
-		log.Printf("Failed to paste board task: %v\n", err)
+		t.errorf("Failed to paste board task: %v", err)
 		return
 	}
 
 	col := &t.boardColsData[t.focusedCol]
 	col.InsertTask(&cpy, idx+1)
-	col.UpdatePriorities(idx)
+	col.Reprioritize(col.IndexOf(cpy.Task))
 	t.updateColumn(t.focusedCol)
 
 	// Find and remove tree view node that references target task.
 	for _, node := range parentNode.GetChildren() {
 		c, ok := getColRef(node)
 		if !ok {
-			log.Println("Failed to update tree view: tree view node isn't of type Column.")
+			t.errorf("Failed to update tree view: tree view node isn't of type Column")
 			return
 		}
 		if c == col {
@@ -1245,12 +1379,12 @@ func (t *TUI) pasteBoardTask(row int) {
 		}
 	}
 
-	log.Println("Failed to update tree view: couldn't column find tree view node.")
+	t.errorf("Failed to update tree view: couldn't find column tree view node")
 }
 
 // toggleBoardTaskDesc toggles a board task description.
 func (t *TUI) toggleBoardTaskDesc(row int) {
-	lineWidth := calcColWidth(t.rightPanelWidth, len(t.boardColsData))
+	lineWidth := t.colWidth()
 	idx := t.calcTaskIdxBoard(row, lineWidth)
 	// If calculated task index is within bounds, toggle task show
 	// description status, and update rendered list.
@@ -1268,7 +1402,7 @@ func (t *TUI) removeRefBoard(b *tasks.Board) {
 	for _, id := range b.GetChildren() {
 		board, err := t.treeData.GetBoard(id)
 		if err != nil {
-			log.Printf("Failed to buffer a referenced child board: %v\n", err)
+			t.errorf("Failed to buffer a referenced child board: %v", err)
 			continue
 		}
 		t.treeData.BoardBuff.AddChild(board)
@@ -1281,12 +1415,12 @@ func (t *TUI) removeRefBoard(b *tasks.Board) {
 func (t *TUI) removeRefCol(task tasks.BoardTask, parentBoard *tasks.Board) {
 	board, err := t.treeData.GetBoard(task.GetChildID())
 	if err != nil {
-		log.Printf("Failed to buffer a referenced board in the column: %v\n", err)
+		t.errorf("Failed to buffer a referenced board in the column: %v", err)
 		return
 	}
 	// Remove and buffer immediate referenced child
 	if _, err = t.treeData.RemoveChildBoard(board); err != nil {
-		log.Printf("Failed to remove and buffer child board: %v\n", err)
+		t.errorf("Failed to remove and buffer child board: %v", err)
 		return
 	}
 	t.treeData.ColBuff.AddChild(board)
@@ -1295,7 +1429,7 @@ func (t *TUI) removeRefCol(task tasks.BoardTask, parentBoard *tasks.Board) {
 	// Note: Connection from task to child board must not be severed to
 	// allow pasting in the future.
 	if err = parentBoard.RemoveChild(board.GetID()); err != nil {
-		log.Printf("Failed to remove child board from parent child boards slice: %v\n", err)
+		t.errorf("Failed to remove child board from parent child boards slice: %v", err)
 		return
 	}
 
@@ -1309,7 +1443,7 @@ func (t *TUI) removeBoardRefCol(b *tasks.Board) {
 	for _, id := range b.GetChildren() {
 		board, err := t.treeData.GetBoard(id)
 		if err != nil {
-			log.Printf("Failed to buffer a referenced child board: %v\n", err)
+			t.errorf("Failed to buffer a referenced child board: %v", err)
 			continue
 		}
 		t.treeData.ColBuff.AddChild(board)
@@ -1322,14 +1456,14 @@ func (t *TUI) removeBoardRefCol(b *tasks.Board) {
 func (t *TUI) removeRefTask(task *tasks.BoardTask, parentBoard *tasks.Board) {
 	board, err := t.treeData.GetBoard(task.GetChildID())
 	if err != nil {
-		log.Printf("Failed to buffer referenced board: %v\n", err)
+		t.errorf("Failed to buffer referenced board: %v", err)
 		return
 	}
 
 	// Remove and buffer immediate referenced child
 	_, err = t.treeData.RemoveChildBoard(board)
 	if err != nil {
-		log.Printf("Failed to remove and buffer child board: %v\n", err)
+		t.errorf("Failed to remove and buffer child board: %v", err)
 		return
 	}
 	t.treeData.TaskBuff.AddChild(board)
@@ -1348,7 +1482,7 @@ func (t *TUI) removeBoardRefTask(b *tasks.Board) {
 	for _, id := range b.GetChildren() {
 		board, err := t.treeData.GetBoard(id)
 		if err != nil {
-			log.Printf("Failed to buffer a referenced child board: %v\n", err)
+			t.errorf("Failed to buffer a referenced child board: %v", err)
 			continue
 		}
 		t.treeData.TaskBuff.AddChild(board)
@@ -1376,36 +1510,51 @@ func (t *TUI) showModal(form *tview.Form) {
 // createListForm creates and returns a tview form for creating a new
 // todo list task.
 func (t *TUI) createListForm(idx int) *tview.Form {
-	var name, description string
-	var isCore bool
+	var name, description, dueDate, checklist string
+	var isCore, blocked bool
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Create New Task")
 
 	form.AddInputField("Name", "", 20, nil, func(text string) {
 		name = text
 	})
-	form.AddInputField("Description", "", 50, nil, func(text string) {
+	form.AddTextArea("Description", "", 50, 4, 0, func(text string) {
 		description = text
 	})
+	form.AddInputField("Due (YYYY-MM-DD)", "", 10, nil, func(text string) {
+		dueDate = text
+	})
 	form.AddCheckbox("Is Core Task", false, func(checked bool) {
 		isCore = checked
 	})
+	form.AddCheckbox("Blocked", false, func(checked bool) {
+		blocked = checked
+	})
+	form.AddTextArea("Checklist", "", 50, 4, 0, func(text string) {
+		checklist = text
+	})
 
 	form.AddButton("Save", func() {
+		due, err := parseDue(dueDate)
+		if err != nil {
+			t.errorf("Failed to create task: %v", err)
+			return
+		}
 		// Add task to task data slice
 		task := new(tasks.TodoTask)
 		task.SetTask(new(tasks.Task))
 		task.SetStarted(time.Now())
 		task.SetName(name)
 		task.SetDesc(description)
+		task.SetDue(due)
+		task.SetBlocked(blocked)
 		task.SetCore(isCore)
-		task.SetPriority(idx + 1)
+		task.SetChecklist(parseChecklist(checklist))
 		t.taskData.IncrementTaskCtr()
 		task.SetID(t.taskData.GetTaskCtr())
 		t.taskData.Add(task, idx+1)
-		t.taskData.UpdatePriorities(idx + 1)
+		t.taskData.Reprioritize(t.taskData.IndexOf(task.Task))
 
 		// Update tview list
 		t.filterAndUpdateList(t.leftPanelWidth)
@@ -1428,8 +1577,7 @@ func (t *TUI) createListForm(idx int) *tview.Form {
 func (t *TUI) createRootBoardForm() *tview.Form {
 	var name string
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Create New Root Board")
 
 	form.AddInputField("Name", name, 20, nil, func(text string) {
@@ -1467,22 +1615,36 @@ func (t *TUI) createColForm(focusedCol int) (*tview.Form, error) {
 		return nil, errors.New("current tree view node isn't of type Board")
 		t.closeModal()
 	}
-	var name string
+	var name, wipLimit string
+	var done bool
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Create New Board Column")
 
 	form.AddInputField("Name", "", 20, nil, func(text string) {
 		name = text
 	})
+	form.AddInputField("WIP limit", "", 5, nil, func(text string) {
+		wipLimit = text
+	})
+	form.AddCheckbox("Done column", false, func(checked bool) {
+		done = checked
+	})
 
 	form.AddButton("Save", func() {
+		limit, err := parseWIPLimit(wipLimit)
+		if err != nil {
+			t.errorf("Failed to create board column: %v", err)
+			return
+		}
 		column := new(tasks.BoardColumn)
 		column.SetTitle(name)
+		column.SetWIPLimit(limit)
+		column.SetDoneColumn(done)
 
 		// Insert new column
 		board.InsertColumn(*column, t.focusedCol+1)
+		t.shiftCollapsedCols(board, t.focusedCol+1, 1)
 
 		// Update the open board
 		t.showBoard(board)
@@ -1513,44 +1675,67 @@ func (t *TUI) createColForm(focusedCol int) (*tview.Form, error) {
 // new board task. This function makes the assumption that a task is
 // currently selected.
 func (t *TUI) createBoardTaskForm(idx int) *tview.Form {
-	var name, description string
-	var createChildBoard bool
+	var name, description, dueDate, checklist string
+	var createChildBoard, blocked bool
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Create New Task")
 
 	form.AddInputField("Name", "", 20, nil, func(text string) {
 		name = text
 	})
-	form.AddInputField("Description", "", 50, nil, func(text string) {
+	form.AddTextArea("Description", "", 50, 4, 0, func(text string) {
 		description = text
 	})
+	form.AddInputField("Due (YYYY-MM-DD)", "", 10, nil, func(text string) {
+		dueDate = text
+	})
+	form.AddCheckbox("Blocked", false, func(checked bool) {
+		blocked = checked
+	})
+	form.AddTextArea("Checklist", "", 50, 4, 0, func(text string) {
+		checklist = text
+	})
 	form.AddCheckbox("Create a Board?", false, func(checked bool) {
 		createChildBoard = checked
 	})
 
 	form.AddButton("Save", func() {
+		due, err := parseDue(dueDate)
+		if err != nil {
+			t.errorf("Failed to create board task: %v", err)
+			return
+		}
+		if err := t.checkWIP(&t.boardColsData[t.focusedCol]); err != nil {
+			t.errorf("Failed to create board task: %v", err)
+			return
+		}
 		// Add task to task data slice
 		task := new(tasks.BoardTask)
 		task.SetTask(new(tasks.Task))
-		task.SetPriority(idx + 1)
 		t.treeData.IncrementTaskCtr()
 		task.SetID(t.treeData.GetTaskCtr())
 		task.SetStarted(time.Now())
 		task.SetName(name)
 		task.SetDesc(description)
+		task.SetDue(due)
+		task.SetBlocked(blocked)
+		task.SetChecklist(parseChecklist(checklist))
 		task.SetChildID(-1)
 
 		if createChildBoard {
 			if err := t.createAndAddChildBoard(name, task); err != nil {
-				log.Printf("Failed to create and add child board for %q task: %v\n", name, err)
+				t.errorf("Failed to create and add child board for %q task: %v", name, err)
 			}
 		}
 
 		col := &t.boardColsData[t.focusedCol]
+		// Add the task to the lane of the task it is added below.
+		if prev, err := col.GetTask(idx); err == nil {
+			task.SetLane(prev.GetLane())
+		}
 		col.InsertTask(task, idx+1)
-		col.UpdatePriorities(idx)
+		col.Reprioritize(col.IndexOf(task.Task))
 
 		// Update the column to show the newly added task
 		t.updateColumn(t.focusedCol)
@@ -1571,7 +1756,7 @@ func (t *TUI) createBoardTaskForm(idx int) *tview.Form {
 		for _, node := range parentNode.GetChildren() {
 			c, ok := getColRef(node)
 			if !ok {
-				log.Println("Failed to create board task: tree view node isn't of type Column.")
+				t.errorf("Failed to create board task: tree view node isn't of type Column")
 				t.closeModal()
 				return
 			}
@@ -1585,7 +1770,7 @@ func (t *TUI) createBoardTaskForm(idx int) *tview.Form {
 		}
 
 		if foundCol == false {
-			log.Println("Failed to update tree view: tree view column node not found")
+			t.errorf("Failed to update tree view: tree view column node not found")
 		}
 
 		t.closeModal()
@@ -1626,10 +1811,12 @@ func (t *TUI) editListForm(idx int) (*tview.Form, error) {
 	}
 	name := task.GetName()
 	description := task.GetDesc()
+	dueDate := formatDue(task.GetDue())
 	isCore := task.GetIsCore()
+	blocked := task.GetBlocked()
+	checklist := formatChecklist(task.GetChecklist())
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Edit Task")
 
 	// Define the input fields for the forms and update field variables if
@@ -1637,18 +1824,35 @@ func (t *TUI) editListForm(idx int) (*tview.Form, error) {
 	form.AddInputField("Name", task.GetName(), 20, nil, func(text string) {
 		name = text
 	})
-	form.AddInputField("Description", task.GetDesc(), 50, nil, func(text string) {
+	form.AddTextArea("Description", task.GetDesc(), 50, 4, 0, func(text string) {
 		description = text
 	})
+	form.AddInputField("Due (YYYY-MM-DD)", dueDate, 10, nil, func(text string) {
+		dueDate = text
+	})
 	form.AddCheckbox("Is Core Task", task.GetIsCore(), func(checked bool) {
 		isCore = checked
 	})
+	form.AddCheckbox("Blocked", blocked, func(checked bool) {
+		blocked = checked
+	})
+	form.AddTextArea("Checklist", checklist, 50, 4, 0, func(text string) {
+		checklist = text
+	})
 
 	form.AddButton("Save", func() {
+		due, err := parseDue(dueDate)
+		if err != nil {
+			t.errorf("Failed to edit task: %v", err)
+			return
+		}
 		// Update task in data slice
 		task.SetName(name)
 		task.SetDesc(description)
+		task.SetDue(due)
+		task.SetBlocked(blocked)
 		task.SetCore(isCore)
+		task.SetChecklist(parseChecklist(checklist))
 
 		// Update tview list
 		t.filterAndUpdateList(t.leftPanelWidth)
@@ -1671,8 +1875,7 @@ func (t *TUI) editListForm(idx int) (*tview.Form, error) {
 func (t *TUI) editRootBoardForm(board *tasks.Board, node *tview.TreeNode) *tview.Form {
 	name := board.GetTitle()
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Edit Root Board")
 
 	// Define the input fields for the forms and update field variables if
@@ -1703,9 +1906,10 @@ func (t *TUI) editRootBoardForm(board *tasks.Board, node *tview.TreeNode) *tview
 func (t *TUI) editColForm() *tview.Form {
 	col := &t.boardColsData[t.focusedCol]
 	name := col.GetTitle()
+	wipLimit := formatWIPLimit(col.GetWIPLimit())
+	done := col.IsDoneColumn()
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Edit Column")
 
 	// Define the input fields for the forms and update field variables if
@@ -1713,10 +1917,23 @@ func (t *TUI) editColForm() *tview.Form {
 	form.AddInputField("Name", name, 20, nil, func(text string) {
 		name = text
 	})
+	form.AddInputField("WIP limit", wipLimit, 5, nil, func(text string) {
+		wipLimit = text
+	})
+	form.AddCheckbox("Done column", done, func(checked bool) {
+		done = checked
+	})
 
 	form.AddButton("Save", func() {
+		limit, err := parseWIPLimit(wipLimit)
+		if err != nil {
+			t.errorf("Failed to edit board column: %v", err)
+			return
+		}
 		// Update task in data slice
 		col.SetTitle(name)
+		col.SetWIPLimit(limit)
+		col.SetDoneColumn(done)
 
 		// Update the open board's column
 		t.updateColumn(t.focusedCol)
@@ -1725,7 +1942,7 @@ func (t *TUI) editColForm() *tview.Form {
 		node := t.tree.GetCurrentNode()
 		board, ok := t.getBoardRef(node)
 		if !ok {
-			log.Println("Failed to update tree view: current tree view node isn't of type Board.")
+			t.errorf("Failed to update tree view: current tree view node isn't of type Board")
 			t.closeModal()
 			return
 		}
@@ -1762,9 +1979,11 @@ func (t *TUI) editBoardTaskForm(idx int) (*tview.Form, error) {
 	}
 	name := task.GetName()
 	desc := task.GetDesc()
+	dueDate := formatDue(task.GetDue())
+	blocked := task.GetBlocked()
+	checklist := formatChecklist(task.GetChecklist())
 
-	form := tview.NewForm()
-	form.SetBorder(true)
+	form := t.newForm()
 	form.SetTitle("Edit Task")
 
 	// Define the input fields for the forms and update field variables if
@@ -1772,9 +1991,18 @@ func (t *TUI) editBoardTaskForm(idx int) (*tview.Form, error) {
 	form.AddInputField("Name", name, 20, nil, func(text string) {
 		name = text
 	})
-	form.AddInputField("Description", desc, 50, nil, func(text string) {
+	form.AddTextArea("Description", desc, 50, 4, 0, func(text string) {
 		desc = text
 	})
+	form.AddInputField("Due (YYYY-MM-DD)", dueDate, 10, nil, func(text string) {
+		dueDate = text
+	})
+	form.AddCheckbox("Blocked", blocked, func(checked bool) {
+		blocked = checked
+	})
+	form.AddTextArea("Checklist", checklist, 50, 4, 0, func(text string) {
+		checklist = text
+	})
 
 	if !task.GetHasChild() {
 		form.AddCheckbox("Create a Board?", false, func(checked bool) {
@@ -1783,20 +2011,28 @@ func (t *TUI) editBoardTaskForm(idx int) (*tview.Form, error) {
 	}
 
 	form.AddButton("Save", func() {
+		due, err := parseDue(dueDate)
+		if err != nil {
+			t.errorf("Failed to edit board task: %v", err)
+			return
+		}
 		task.SetName(name)
+		task.SetDue(due)
+		task.SetBlocked(blocked)
 		if task.GetHasChild() {
 			childBoard, err := t.treeData.GetBoard(task.GetChildID())
 			if err != nil {
-				log.Printf("Failed to create and add child board for %q task: %v\n", name, err)
+				t.errorf("Failed to create and add child board for %q task: %v", name, err)
 				return
 			}
 			childBoard.SetTitle(name)
 		}
 		task.SetDesc(desc)
+		task.SetChecklist(parseChecklist(checklist))
 
 		if createChildBoard {
 			if err := t.createAndAddChildBoard(name, task); err != nil {
-				log.Printf("Failed to create and add child board for %q task: %v\n", name, err)
+				t.errorf("Failed to create and add child board for %q task: %v", name, err)
 				return
 			}
 		}
@@ -1821,7 +2057,7 @@ func (t *TUI) editBoardTaskForm(idx int) (*tview.Form, error) {
 		for _, node := range parentNode.GetChildren() {
 			c, ok := getColRef(node)
 			if !ok {
-				log.Println("Failed to create board task: tree view node isn't of type Column.")
+				t.errorf("Failed to create board task: tree view node isn't of type Column")
 				t.closeModal()
 				return
 			}
@@ -1835,7 +2071,7 @@ func (t *TUI) editBoardTaskForm(idx int) (*tview.Form, error) {
 		}
 
 		if foundCol == false {
-			log.Println("Failed to update tree view: tree view column node not found")
+			t.errorf("Failed to update tree view: tree view column node not found")
 		}
 
 		t.closeModal()
@@ -1851,6 +2087,31 @@ func (t *TUI) editBoardTaskForm(idx int) (*tview.Form, error) {
 	return form, nil
 }
 
+// dueLayout is the layout of due dates entered in forms.
+const dueLayout = "2006-01-02"
+
+// parseDue parses a due date entered in a form. An empty string yields
+// the zero time, meaning the task has no due date.
+func parseDue(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	d, err := time.ParseInLocation(dueLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", s)
+	}
+	return d, nil
+}
+
+// formatDue formats a due date for display in a form.
+func formatDue(d time.Time) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dueLayout)
+}
+
 // closeModal removes the modal page
 func (t *TUI) closeModal() {
 	t.pages.RemovePage("modal")
@@ -1868,7 +2129,7 @@ func (t *TUI) getBoardRef(n *tview.TreeNode) (*tasks.Board, bool) {
 
 	board, err := t.treeData.GetBoard(nr.ID)
 	if err != nil {
-		log.Println("Failed to get board reference: ", err)
+		t.errorf("Failed to get board reference: %v", err)
 		return nil, false
 	}
 	return board, true
@@ -1914,6 +2175,53 @@ func severConnection(parentTask *tasks.BoardTask, parentBoard, board *tasks.Boar
 	parentBoard.RemoveChild(board.GetID())
 }
 
+// reloadTree rebuilds the entire tree view and points the current
+// tree node and navigation stack at the given board.
+func (t *TUI) reloadTree(current *tasks.Board) {
+	t.updateTree()
+	node := t.findBoardNode(current.GetID())
+	if node == nil {
+		return
+	}
+	t.tree.SetCurrentNode(node)
+	t.rebuildNavStack(node)
+}
+
+// findBoardNode returns the tree view node that references the board
+// with the given id, or nil if no such node exists.
+func (t *TUI) findBoardNode(id int) *tview.TreeNode {
+	var found *tview.TreeNode
+	t.tree.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+		if found != nil {
+			return false
+		}
+		if nr, ok := node.GetReference().(NodeRef); ok && nr.ID == id {
+			found = node
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// rebuildNavStack replaces the navigation stack with the chain of
+// board nodes leading from a root board down to the given node.
+func (t *TUI) rebuildNavStack(target *tview.TreeNode) {
+	parents := make(map[*tview.TreeNode]*tview.TreeNode)
+	t.tree.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+		parents[node] = parent
+		return true
+	})
+
+	var stack []*tview.TreeNode
+	for n := target; n != nil; n = parents[n] {
+		if _, ok := n.GetReference().(NodeRef); ok {
+			stack = append([]*tview.TreeNode{n}, stack...)
+		}
+	}
+	t.navStack = stack
+}
+
 // push adds the current node to the navigation stack.
 func (t *TUI) push(node *tview.TreeNode) {
 	t.navStack = append(t.navStack, node)
@@ -2,34 +2,17 @@ package ui
 
 import "fmt"
 
-func ExampleWordWrap() {
-	s := "The quick brown fox jumps over the lazy dog."
-	lines := WordWrap(s, 15)
-	for _, line := range lines {
-		fmt.Println(line)
-	}
+func Example_shownNeighbour() {
+	// Tasks 1 and 2 are hidden by a filter.
+	shown := func(i int) bool { return i != 1 && i != 2 }
+	fmt.Println(shownNeighbour(5, 0, 1, shown))
+	fmt.Println(shownNeighbour(5, 3, -1, shown))
+	fmt.Println(shownNeighbour(5, 3, 5, shown))
+	fmt.Println(shownNeighbour(5, 0, -1, shown))
 
 	// Output:
-	// The quick brown
-	// fox jumps over
-	// the lazy dog.
-}
-
-func ExampleWordWrap_zeroWidth() {
-	s := "The quick brown fox jumps over the lazy dog."
-	lines := WordWrap(s, 0)
-	for _, line := range lines {
-		fmt.Println(line)
-	}
-
-	// Output:
-	// The
-	// quick
-	// brown
-	// fox
-	// jumps
-	// over
-	// the
-	// lazy
-	// dog.
+	// 3
+	// 0
+	// 4
+	// 0
 }
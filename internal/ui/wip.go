@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+// doneColumnMark follows the title of done columns.
+const doneColumnMark = " ✓"
+
+// columnTitle returns the header of a board column: its title with the
+// prioritizer ordering it, its task count against its WIP limit and a
+// mark if it is a done column.
+func columnTitle(col tasks.BoardColumn) string {
+	title := col.GetTitle()
+	if p := col.GetPrioritizer(); p != "" && p != tasks.Manual {
+		title += " (" + p + ")"
+	}
+	if limit := col.GetWIPLimit(); limit > 0 {
+		title += fmt.Sprintf(" %d/%d", len(col.GetTasks()), limit)
+	}
+	if col.IsDoneColumn() {
+		title += doneColumnMark
+	}
+	return title
+}
+
+// checkWIP checks whether a task may be added to the given column. If
+// that exceeds the WIP limit of the column, it returns an error if the
+// limits block, and warns otherwise.
+func (t *TUI) checkWIP(col *tasks.BoardColumn) error {
+	if !col.AtWIPLimit() {
+		return nil
+	}
+	if t.blockWIP {
+		return fmt.Errorf("column %q is at its WIP limit of %d", col.GetTitle(), col.GetWIPLimit())
+	}
+	t.warnf("Column %q is over its WIP limit of %d", col.GetTitle(), col.GetWIPLimit())
+	return nil
+}
+
+// parseWIPLimit parses a WIP limit as typed into a form, where an empty
+// field means no limit.
+func parseWIPLimit(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid WIP limit %q", s)
+	}
+	return n, nil
+}
+
+// formatWIPLimit formats a WIP limit for a form field.
+func formatWIPLimit(limit int) string {
+	if limit == 0 {
+		return ""
+	}
+	return strconv.Itoa(limit)
+}
+
+// runWIP sets the WIP limit of the focused column. Without a limit, or
+// with zero, the column has no limit.
+func runWIP(t *TUI, _ context, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: wip [limit]")
+	}
+	limit := 0
+	if len(args) == 1 {
+		var err error
+		if limit, err = parseWIPLimit(args[0]); err != nil {
+			return err
+		}
+	}
+	col := &t.boardColsData[t.focusedCol]
+	if err := col.SetWIPLimit(limit); err != nil {
+		return err
+	}
+	t.updateColumn(t.focusedCol)
+	return nil
+}
+
+// runDoneColumn marks the focused column as a done column, or unmarks
+// it.
+func runDoneColumn(t *TUI, _ context, _ []string) error {
+	col := &t.boardColsData[t.focusedCol]
+	col.SetDoneColumn(!col.IsDoneColumn())
+	t.updateColumn(t.focusedCol)
+	if col.IsDoneColumn() {
+		t.infof("Tasks entering %q are done", col.GetTitle())
+	}
+	if b, err := t.currentBoard(); err == nil {
+		t.completeParents(b)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/ericstrs/bp/internal/tasks"
+)
+
+func Example_columnTitle() {
+	col := tasks.BoardColumn{Title: "Doing"}
+	col.Add(&tasks.BoardTask{Task: &tasks.Task{Name: "code"}})
+	fmt.Println(columnTitle(col))
+	col.SetWIPLimit(3)
+	fmt.Println(columnTitle(col))
+	col.SetDoneColumn(true)
+	fmt.Println(columnTitle(col))
+
+	// Output:
+	// Doing
+	// Doing 1/3
+	// Doing 1/3 ✓
+}
+
+func Example_parseWIPLimit() {
+	fmt.Println(parseWIPLimit(""))
+	fmt.Println(parseWIPLimit(" 4 "))
+	fmt.Println(parseWIPLimit("-1"))
+
+	// Output:
+	// 0 <nil>
+	// 4 <nil>
+	// 0 invalid WIP limit "-1"
+}